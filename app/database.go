@@ -1,52 +1,87 @@
 package app
 
 import (
+	"context"
 	"fmt"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"inventory-management-system/config"
 	"inventory-management-system/model/domain"
+	"log/slog"
 	"time"
 )
 
-type Postgres struct{}
+const maxConnectBackoff = 30 * time.Second
 
-func (p *Postgres) Connect(credential *domain.Credential) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Asia/Jakarta",
-		credential.Host, credential.Username, credential.Password, credential.DatabaseName, credential.Port)
+type Database interface {
+	Connect() (*gorm.DB, error)
+}
 
-	dbConn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
-	if err != nil {
-		return nil, err
+func NewDatabase(database config.Database) (Database, error) {
+	switch database.Driver {
+	case "postgres":
+		return NewPostgres(domain.Credential{
+			Host:         database.Host,
+			Username:     database.Username,
+			Password:     database.Password,
+			DatabaseName: database.Name,
+			Port:         database.Port,
+			Schema:       database.Schema,
+		}), nil
+	case "sqlite":
+		return NewSQLite(database.SQLitePath), nil
+	default:
+		return nil, fmt.Errorf("database: unknown driver %q", database.Driver)
 	}
+}
 
-	db, err := dbConn.DB()
-	if err != nil {
-		return nil, err
+func ConnectWithRetry(ctx context.Context, database Database, attempts int, backoff time.Duration) (*gorm.DB, error) {
+	if attempts < 1 {
+		attempts = 1
 	}
 
-	db.SetMaxOpenConns(100)
-	db.SetMaxIdleConns(10)
-	db.SetConnMaxLifetime(30 * time.Minute)
-	db.SetConnMaxIdleTime(5 * time.Minute)
+	var err error
+	for attempt := 1; ; attempt++ {
+		var db *gorm.DB
+		db, err = database.Connect()
+		if err == nil {
+			return db, nil
+		}
+
+		if attempt == attempts {
+			break
+		}
 
-	return dbConn, nil
-}
+		slog.Warn("database connect failed", "attempt", attempt, "attempts", attempts, "retry_in", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
 
-func NewDB() *Postgres {
-	return &Postgres{}
+	return nil, fmt.Errorf("database: giving up after %d attempts: %w", attempts, err)
 }
 
-func (p *Postgres) Reset(db *gorm.DB, table string) error {
-	return db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Exec("TRUNCATE " + table).Error; err != nil {
-			return err
-		}
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
 
-		if err := tx.Exec("ALTER SEQUENCE " + table + "_id_seq RESTART WITH 1").Error; err != nil {
-			return err
-		}
+	return sqlDB.Close()
+}
 
+func ClearEmptyEmails(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&domain.Users{}) {
 		return nil
-	})
+	}
+
+	return db.Unscoped().Model(&domain.Users{}).Where("email = ?", "").UpdateColumn("email", nil).Error
+}
+
+func gormConfig() *gorm.Config {
+	return &gorm.Config{Logger: logger.Default.LogMode(logger.Silent), TranslateError: true}
 }
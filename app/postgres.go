@@ -0,0 +1,39 @@
+package app
+
+import (
+	"fmt"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"inventory-management-system/model/domain"
+	"time"
+)
+
+type Postgres struct {
+	credential domain.Credential
+}
+
+func NewPostgres(credential domain.Credential) Database {
+	return &Postgres{credential}
+}
+
+func (p *Postgres) Connect() (*gorm.DB, error) {
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Asia/Jakarta",
+		p.credential.Host, p.credential.Username, p.credential.Password, p.credential.DatabaseName, p.credential.Port)
+
+	dbConn, err := gorm.Open(postgres.Open(dsn), gormConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := dbConn.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(100)
+	db.SetMaxIdleConns(10)
+	db.SetConnMaxLifetime(30 * time.Minute)
+	db.SetConnMaxIdleTime(5 * time.Minute)
+
+	return dbConn, nil
+}
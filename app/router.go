@@ -2,40 +2,109 @@ package app
 
 import (
 	"github.com/gin-gonic/gin"
+	"inventory-management-system/config"
 	"inventory-management-system/controller"
+	"inventory-management-system/metrics"
 	"inventory-management-system/middleware"
+	"inventory-management-system/ratelimit"
+	"inventory-management-system/signing"
+	"net/http"
 )
 
-func UserRouter(apiServer *gin.Engine, userController controller.UserController) *gin.Engine {
+func UserRouter(apiServer *gin.Engine, userController controller.UserController, authenticate gin.HandlerFunc, loginLimiter ratelimit.Limiter, timeouts config.Timeouts) *gin.Engine {
 	user := apiServer.Group("/api/v1")
-	user.POST("/login", userController.Login)
+	user.Use(middleware.Timeout(timeouts.Default))
+	user.POST("/login", middleware.RateLimit(loginLimiter), userController.Login)
+	user.POST("/login/mfa", middleware.RateLimit(loginLimiter), userController.VerifyMFA)
+	user.POST("/login/mfa/enroll", middleware.RateLimit(loginLimiter), userController.EnrollMFA)
+	user.POST("/token/refresh", middleware.RateLimit(loginLimiter), middleware.CSRF(), userController.Refresh)
+
+	user.Use(authenticate)
+	user.Use(middleware.CSRF())
+	user.POST("/logout", userController.Logout)
 
-	user.Use(middleware.Auth())
 	user.Use(middleware.AdminOnly())
 	user.POST("/users", userController.Register)
 	user.GET("/users", userController.GetAll)
 	user.GET("/users/:username", userController.GetByUsername)
 	user.PUT("/users/:username", userController.Update)
 	user.DELETE("/users/:username", userController.Delete)
+	user.POST("/users/:username/deactivate", userController.Deactivate)
+	user.POST("/users/:username/reactivate", userController.Reactivate)
+	user.GET("/login-attempts", userController.GetLoginAttempts)
+
+	return apiServer
+}
+
+func ProfileRouter(apiServer *gin.Engine, profileController controller.ProfileController, authenticate gin.HandlerFunc, timeouts config.Timeouts) *gin.Engine {
+	profile := apiServer.Group("/api/v1/me")
+	profile.Use(middleware.Timeout(timeouts.Default))
+	profile.Use(authenticate)
+	profile.Use(middleware.CSRF())
+	profile.GET("", profileController.Get)
+	profile.PUT("", profileController.Update)
+	profile.POST("/password", profileController.ChangePassword)
+
+	return apiServer
+}
+
+func PasswordResetRouter(apiServer *gin.Engine, passwordResetController controller.PasswordResetController, loginLimiter ratelimit.Limiter, timeouts config.Timeouts) *gin.Engine {
+	passwordReset := apiServer.Group("/api/v1/password")
+	passwordReset.Use(middleware.Timeout(timeouts.Default))
+	passwordReset.Use(middleware.RateLimit(loginLimiter))
+	passwordReset.POST("/forgot", passwordResetController.Request)
+	passwordReset.POST("/reset", passwordResetController.Confirm)
+
+	return apiServer
+}
+
+func OIDCRouter(apiServer *gin.Engine, oidcController controller.OIDCController, loginLimiter ratelimit.Limiter, timeouts config.Timeouts) *gin.Engine {
+	sso := apiServer.Group("/api/v1/oidc")
+	sso.Use(middleware.Timeout(timeouts.Default))
+	sso.Use(middleware.RateLimit(loginLimiter))
+	sso.GET("/login", oidcController.Login)
+	sso.GET("/callback", oidcController.Callback)
 
 	return apiServer
 }
 
-func CategoryRouter(apiServer *gin.Engine, categoryController controller.CategoryController) *gin.Engine {
+func TwoFactorRouter(apiServer *gin.Engine, twoFactorController controller.TwoFactorController, authenticate gin.HandlerFunc, timeouts config.Timeouts) *gin.Engine {
+	twoFactor := apiServer.Group("/api/v1")
+	twoFactor.Use(middleware.Timeout(timeouts.Default))
+	twoFactor.Use(authenticate)
+	twoFactor.Use(middleware.CSRF())
+	twoFactor.POST("/mfa/totp", twoFactorController.Enroll)
+	twoFactor.POST("/mfa/totp/confirm", twoFactorController.Confirm)
+	twoFactor.DELETE("/mfa/totp", twoFactorController.Disable)
+	twoFactor.DELETE("/users/:username/mfa", middleware.AdminOnly(), twoFactorController.Reset)
+
+	return apiServer
+}
+
+func CategoryRouter(apiServer *gin.Engine, categoryController controller.CategoryController, authenticate gin.HandlerFunc, timeouts config.Timeouts) *gin.Engine {
 	category := apiServer.Group("/api/v1")
-	category.Use(middleware.Auth())
+	category.Use(middleware.Timeout(timeouts.Default))
+	category.Use(authenticate)
+	category.Use(middleware.CSRF())
+	category.Use(middleware.Scope("categories"))
 	category.GET("/category", categoryController.GetAll)
 	category.PUT("/category/:categoryID", categoryController.Update)
 	category.DELETE("/category/:categoryID", categoryController.Delete)
 	category.POST("/category", categoryController.Add)
 	category.GET("/category/:categoryID", categoryController.GetByID)
+	category.GET("/category/:categoryID/attributes", categoryController.GetAttributes)
+	category.POST("/category/:categoryID/attributes", categoryController.AddAttribute)
+	category.DELETE("/category/:categoryID/attributes/:attributeID", categoryController.DeleteAttribute)
 
 	return apiServer
 }
 
-func ItemRouter(apiServer *gin.Engine, itemController controller.ItemController) *gin.Engine {
+func ItemRouter(apiServer *gin.Engine, itemController controller.ItemController, authenticate gin.HandlerFunc, timeouts config.Timeouts) *gin.Engine {
 	item := apiServer.Group("/api/v1")
-	item.Use(middleware.Auth())
+	item.Use(middleware.Timeout(timeouts.Default))
+	item.Use(authenticate)
+	item.Use(middleware.CSRF())
+	item.Use(middleware.Scope("items"))
 	item.GET("/items", itemController.GetAll)
 	item.GET("/items/:itemID", itemController.GetByID)
 	item.PUT("/items/:itemID", itemController.Update)
@@ -45,11 +114,118 @@ func ItemRouter(apiServer *gin.Engine, itemController controller.ItemController)
 	return apiServer
 }
 
-func ReportRouter(apiServer *gin.Engine, reportController controller.ReportController) *gin.Engine {
+func ReportRouter(apiServer *gin.Engine, reportController controller.ReportController, authenticate gin.HandlerFunc, timeouts config.Timeouts) *gin.Engine {
 	report := apiServer.Group("/api/v1/reports")
-	report.Use(middleware.Auth())
+	report.Use(middleware.Timeout(timeouts.Report))
+	report.Use(authenticate)
+	report.Use(middleware.CSRF())
+	report.Use(middleware.Scope("reports"))
 	report.GET("/activity", reportController.GetAllActivity)
 	report.GET("/stock/:itemStock", reportController.ReportStock)
 
 	return apiServer
 }
+
+func AssignmentRouter(apiServer *gin.Engine, assignmentController controller.AssignmentController, authenticate gin.HandlerFunc, timeouts config.Timeouts) *gin.Engine {
+	assignment := apiServer.Group("/api/v1")
+	assignment.Use(middleware.Timeout(timeouts.Default))
+	assignment.Use(authenticate)
+	assignment.Use(middleware.CSRF())
+	assignment.Use(middleware.Scope("assignments"))
+	assignment.GET("/assignments", assignmentController.GetAll)
+	assignment.GET("/assignments/overdue", assignmentController.GetOverdue)
+	assignment.GET("/assignments/mine", assignmentController.GetMine)
+	assignment.GET("/assignments/holders/:username", assignmentController.GetByAssignee)
+	assignment.POST("/assignments/checkout", assignmentController.CheckOut)
+	assignment.POST("/assignments/:assignmentID/checkin", assignmentController.CheckIn)
+
+	return apiServer
+}
+
+func ItemUnitRouter(apiServer *gin.Engine, itemUnitController controller.ItemUnitController, authenticate gin.HandlerFunc, timeouts config.Timeouts) *gin.Engine {
+	unit := apiServer.Group("/api/v1")
+	unit.Use(middleware.Timeout(timeouts.Default))
+	unit.Use(authenticate)
+	unit.Use(middleware.CSRF())
+	unit.Use(middleware.Scope("units"))
+	unit.GET("/items/:itemID/units", itemUnitController.GetByItemID)
+	unit.POST("/items/:itemID/units", itemUnitController.Add)
+	unit.GET("/units/:unitID", itemUnitController.GetByID)
+	unit.PUT("/units/:unitID", itemUnitController.Update)
+	unit.GET("/units/serial/:serialNumber", itemUnitController.GetBySerialNumber)
+	unit.GET("/units/tag/:assetTag", itemUnitController.GetByAssetTag)
+
+	return apiServer
+}
+
+func LabelRouter(apiServer *gin.Engine, labelController controller.LabelController, authenticate gin.HandlerFunc, timeouts config.Timeouts) *gin.Engine {
+	label := apiServer.Group("/api/v1")
+	label.Use(middleware.Timeout(timeouts.Default))
+	label.Use(authenticate)
+	label.Use(middleware.CSRF())
+	label.Use(middleware.RequireScope("labels:read"))
+	label.GET("/items/:itemID/label", labelController.ItemLabel)
+	label.GET("/units/:unitID/label", labelController.UnitLabel)
+	label.POST("/labels", labelController.BatchLabels)
+	label.GET("/scan/:code", labelController.Scan)
+
+	return apiServer
+}
+
+func AttachmentRouter(apiServer *gin.Engine, attachmentController controller.AttachmentController, authenticate gin.HandlerFunc, maxUploadSize int64, timeouts config.Timeouts) *gin.Engine {
+	upload := apiServer.Group("/api/v1")
+	upload.Use(middleware.Timeout(timeouts.Upload))
+	upload.Use(authenticate)
+	upload.Use(middleware.CSRF())
+	upload.Use(middleware.Scope("attachments"))
+	upload.POST("/items/:itemID/attachments", middleware.BodyLimit(maxUploadSize+1<<20), attachmentController.Upload)
+
+	attachment := apiServer.Group("/api/v1")
+	attachment.Use(middleware.Timeout(timeouts.Default))
+	attachment.Use(authenticate)
+	attachment.Use(middleware.CSRF())
+	attachment.Use(middleware.Scope("attachments"))
+	attachment.GET("/items/:itemID/attachments", attachmentController.GetByItemID)
+	attachment.GET("/attachments/:attachmentID", attachmentController.Download)
+	attachment.GET("/attachments/:attachmentID/thumbnail", attachmentController.Thumbnail)
+	attachment.DELETE("/attachments/:attachmentID", attachmentController.Delete)
+
+	return apiServer
+}
+
+func APIKeyRouter(apiServer *gin.Engine, apiKeyController controller.APIKeyController, authenticate gin.HandlerFunc, timeouts config.Timeouts) *gin.Engine {
+	apiKey := apiServer.Group("/api/v1")
+	apiKey.Use(middleware.Timeout(timeouts.Default))
+	apiKey.Use(authenticate)
+	apiKey.Use(middleware.CSRF())
+	apiKey.Use(middleware.AdminOnly())
+	apiKey.POST("/api-keys", apiKeyController.Create)
+	apiKey.GET("/api-keys", apiKeyController.GetAll)
+	apiKey.DELETE("/api-keys/:apiKeyID", apiKeyController.Revoke)
+
+	return apiServer
+}
+
+func HealthRouter(apiServer *gin.Engine, healthController controller.HealthController, timeouts config.Timeouts) *gin.Engine {
+	health := apiServer.Group("/")
+	health.Use(middleware.Timeout(timeouts.Default))
+	health.GET("/healthz", healthController.Live)
+	health.GET("/readyz", healthController.Ready)
+
+	return apiServer
+}
+
+func JWKSRouter(apiServer *gin.Engine, keys signing.KeySet) *gin.Engine {
+	apiServer.GET("/.well-known/jwks.json", func(ctx *gin.Context) {
+		ctx.Header("Cache-Control", "public, max-age=300")
+		ctx.JSON(http.StatusOK, keys.JWKS())
+	})
+
+	return apiServer
+}
+
+func MetricsRouter(apiServer *gin.Engine, recorder metrics.Metrics) *gin.Engine {
+	apiServer.GET("/metrics", gin.WrapH(recorder.Handler()))
+
+	return apiServer
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+type SQLite struct {
+	path string
+}
+
+func NewSQLite(path string) Database {
+	return &SQLite{path}
+}
+
+func (s *SQLite) Connect() (*gorm.DB, error) {
+	dsn := s.path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	if s.path != ":memory:" {
+		dsn += "&_pragma=journal_mode(WAL)"
+	}
+
+	dbConn, err := gorm.Open(sqlite.Open(dsn), gormConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := dbConn.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	return dbConn, nil
+}
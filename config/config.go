@@ -0,0 +1,312 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	Server   Server
+	Database Database
+	Storage  Storage
+	Timeouts Timeouts
+	Metrics  Metrics
+	Log      Log
+	Tracing  Tracing
+	Login    Login
+	Auth     Auth
+	Cookie   Cookie
+	Signing  Signing
+	OIDC     OIDC
+	MFA      MFA
+	Password Password
+	Mail     Mail
+	Admin    Admin
+}
+
+type Server struct {
+	Address         string
+	ShutdownTimeout time.Duration
+}
+
+type Database struct {
+	Driver     string
+	Host       string
+	Port       int
+	Username   string
+	Password   string
+	Name       string
+	Schema     string
+	SQLitePath string
+
+	ConnectAttempts int
+	ConnectBackoff  time.Duration
+}
+
+type Storage struct {
+	Driver        string
+	LocalPath     string
+	S3Endpoint    string
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+	MaxUploadSize int64
+}
+
+type Timeouts struct {
+	Default time.Duration
+	Report  time.Duration
+	Upload  time.Duration
+}
+
+type Metrics struct {
+	ReorderPoint int
+}
+
+type Log struct {
+	Level string
+}
+
+type Tracing struct {
+	Exporter     string
+	OTLPEndpoint string
+	ServiceName  string
+	SampleRatio  float64
+}
+
+type Login struct {
+	IPLimit         int
+	UsernameLimit   int
+	RateWindow      time.Duration
+	MaxFailures     int
+	LockoutDuration time.Duration
+}
+
+type Auth struct {
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+type Cookie struct {
+	Enabled  bool
+	Domain   string
+	Secure   bool
+	SameSite string
+}
+
+type Signing struct {
+	PrivateKeyPath       string
+	VerificationKeyPaths []string
+}
+
+type OIDC struct {
+	Issuer            string
+	ClientID          string
+	ClientSecret      string
+	RedirectURL       string
+	Scopes            []string
+	GroupsClaim       string
+	GroupRoles        map[string]string
+	DefaultRole       string
+	PostLoginRedirect string
+}
+
+type MFA struct {
+	Issuer        string
+	RequiredRoles []string
+	ChallengeTTL  time.Duration
+}
+
+type Password struct {
+	MinLength     int
+	MinClasses    int
+	ResetTokenTTL time.Duration
+	ResetURL      string
+}
+
+type Admin struct {
+	Seed     bool
+	Password string
+}
+
+type Mail struct {
+	Driver       string
+	From         string
+	FilePath     string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	Timeout      time.Duration
+	MaxPending   int
+}
+
+func Load() Config {
+	return Config{
+		Server: Server{
+			Address:         getEnv("SERVER_ADDRESS", ":8080"),
+			ShutdownTimeout: getEnvDuration("SHUTDOWN_TIMEOUT", 15*time.Second),
+		},
+		Database: Database{
+			Driver:     getEnv("DB_DRIVER", "postgres"),
+			Host:       getEnv("DB_HOST", "localhost"),
+			Port:       int(getEnvInt64("DB_PORT", 5432)),
+			Username:   getEnv("DB_USERNAME", "postgres"),
+			Password:   getEnv("DB_PASSWORD", "postgres"),
+			Name:       getEnv("DB_NAME", "inventory_test"),
+			Schema:     getEnv("DB_SCHEMA", "public"),
+			SQLitePath: getEnv("DB_SQLITE_PATH", "inventory.db"),
+
+			ConnectAttempts: int(getEnvInt64("DB_CONNECT_ATTEMPTS", 10)),
+			ConnectBackoff:  getEnvDuration("DB_CONNECT_BACKOFF", time.Second),
+		},
+		Storage: Storage{
+			Driver:        getEnv("STORAGE_DRIVER", "local"),
+			LocalPath:     getEnv("STORAGE_LOCAL_PATH", "uploads"),
+			S3Endpoint:    getEnv("STORAGE_S3_ENDPOINT", ""),
+			S3Region:      getEnv("STORAGE_S3_REGION", "us-east-1"),
+			S3Bucket:      getEnv("STORAGE_S3_BUCKET", ""),
+			S3AccessKey:   getEnv("STORAGE_S3_ACCESS_KEY", ""),
+			S3SecretKey:   getEnv("STORAGE_S3_SECRET_KEY", ""),
+			MaxUploadSize: getEnvInt64("UPLOAD_MAX_SIZE", 10<<20),
+		},
+		Timeouts: Timeouts{
+			Default: getEnvDuration("REQUEST_TIMEOUT", 10*time.Second),
+			Report:  getEnvDuration("REPORT_TIMEOUT", 30*time.Second),
+			Upload:  getEnvDuration("UPLOAD_TIMEOUT", 2*time.Minute),
+		},
+		Metrics: Metrics{
+			ReorderPoint: int(getEnvInt64("REORDER_POINT", 5)),
+		},
+		Log: Log{
+			Level: getEnv("LOG_LEVEL", "info"),
+		},
+		Tracing: Tracing{
+			Exporter:     getEnv("TRACING_EXPORTER", "none"),
+			OTLPEndpoint: getEnv("TRACING_OTLP_ENDPOINT", ""),
+			ServiceName:  getEnv("TRACING_SERVICE_NAME", "inventory-management-system"),
+			SampleRatio:  getEnvFloat64("TRACING_SAMPLE_RATIO", 1),
+		},
+		Login: Login{
+			IPLimit:         int(getEnvInt64("LOGIN_IP_LIMIT", 20)),
+			UsernameLimit:   int(getEnvInt64("LOGIN_USERNAME_LIMIT", 10)),
+			RateWindow:      getEnvDuration("LOGIN_RATE_WINDOW", time.Minute),
+			MaxFailures:     int(getEnvInt64("LOGIN_MAX_FAILURES", 5)),
+			LockoutDuration: getEnvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		},
+		Auth: Auth{
+			AccessTokenTTL:  getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL: getEnvDuration("REFRESH_TOKEN_TTL", 7*24*time.Hour),
+		},
+		Cookie: Cookie{
+			Enabled:  getEnvBool("AUTH_COOKIES", true),
+			Domain:   getEnv("COOKIE_DOMAIN", ""),
+			Secure:   getEnvBool("COOKIE_SECURE", true),
+			SameSite: getEnv("COOKIE_SAME_SITE", "lax"),
+		},
+		Signing: Signing{
+			PrivateKeyPath:       getEnv("JWT_PRIVATE_KEY_PATH", ""),
+			VerificationKeyPaths: getEnvList("JWT_VERIFICATION_KEY_PATHS"),
+		},
+		OIDC: OIDC{
+			Issuer:            getEnv("OIDC_ISSUER", ""),
+			ClientID:          getEnv("OIDC_CLIENT_ID", ""),
+			ClientSecret:      getEnv("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:       getEnv("OIDC_REDIRECT_URL", ""),
+			Scopes:            getEnvList("OIDC_SCOPES"),
+			GroupsClaim:       getEnv("OIDC_GROUPS_CLAIM", "groups"),
+			GroupRoles:        getEnvMap("OIDC_GROUP_ROLES"),
+			DefaultRole:       getEnv("OIDC_DEFAULT_ROLE", "user"),
+			PostLoginRedirect: getEnv("OIDC_POST_LOGIN_REDIRECT", ""),
+		},
+		MFA: MFA{
+			Issuer:        getEnv("MFA_ISSUER", "Inventory Management System"),
+			RequiredRoles: getEnvList("MFA_REQUIRED_ROLES"),
+			ChallengeTTL:  getEnvDuration("MFA_CHALLENGE_TTL", 5*time.Minute),
+		},
+		Password: Password{
+			MinLength:     int(getEnvInt64("PASSWORD_MIN_LENGTH", 8)),
+			MinClasses:    int(getEnvInt64("PASSWORD_MIN_CLASSES", 2)),
+			ResetTokenTTL: getEnvDuration("PASSWORD_RESET_TTL", time.Hour),
+			ResetURL:      getEnv("PASSWORD_RESET_URL", ""),
+		},
+		Mail: Mail{
+			Driver:       getEnv("MAIL_DRIVER", "none"),
+			From:         getEnv("MAIL_FROM", "Inventory Management System <no-reply@localhost>"),
+			FilePath:     getEnv("MAIL_FILE_PATH", "mail"),
+			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:     int(getEnvInt64("SMTP_PORT", 587)),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			Timeout:      getEnvDuration("MAIL_TIMEOUT", 30*time.Second),
+			MaxPending:   int(getEnvInt64("MAIL_MAX_PENDING", 100)),
+		},
+		Admin: Admin{
+			Seed:     getEnvBool("SEED_ADMIN", false),
+			Password: getEnv("ADMIN_PASSWORD", ""),
+		},
+	}
+}
+
+func getEnv(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt64(key string, fallback int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvFloat64(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvMap(key string) map[string]string {
+	values := map[string]string{}
+	for _, pair := range getEnvList(key) {
+		name, value, ok := strings.Cut(pair, "=")
+		if ok {
+			values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+	}
+	return values
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"inventory-management-system/helper"
+	"inventory-management-system/model/web"
+	"inventory-management-system/service"
+	"net/http"
+	"strconv"
+)
+
+type APIKeyController interface {
+	Create(c *gin.Context)
+	Revoke(c *gin.Context)
+	GetAll(c *gin.Context)
+}
+
+type apiKeyControllerImpl struct {
+	service.APIKeyService
+	*validator.Validate
+}
+
+func NewAPIKeyController(apiKeyService service.APIKeyService, validate *validator.Validate) APIKeyController {
+	return &apiKeyControllerImpl{apiKeyService, validate}
+}
+
+func (a *apiKeyControllerImpl) Create(c *gin.Context) {
+	var apiKeyCreateRequest web.APIKeyCreateRequest
+	if err := helper.ReadFromRequestBody(c, &apiKeyCreateRequest); err != nil {
+		return
+	}
+
+	err := a.Validate.Struct(apiKeyCreateRequest)
+	if err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	username, _ := c.Get("username")
+	apiKey, errResponse := a.APIKeyService.Create(c.Request.Context(), apiKeyCreateRequest, username.(string))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusCreated, web.NewStatusCreatedData("success create api key", apiKey))
+}
+
+func (a *apiKeyControllerImpl) Revoke(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("apiKeyID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	errResponse := a.APIKeyService.Revoke(c.Request.Context(), id)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("success revoke api key"))
+}
+
+func (a *apiKeyControllerImpl) GetAll(c *gin.Context) {
+	apiKeys, errResponse := a.APIKeyService.GetAll(c.Request.Context())
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success get all api keys", apiKeys))
+}
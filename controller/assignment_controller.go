@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"inventory-management-system/helper"
+	"inventory-management-system/model/web"
+	"inventory-management-system/service"
+	"net/http"
+	"strconv"
+)
+
+type AssignmentController interface {
+	CheckOut(c *gin.Context)
+	CheckIn(c *gin.Context)
+	GetAll(c *gin.Context)
+	GetOverdue(c *gin.Context)
+	GetByAssignee(c *gin.Context)
+	GetMine(c *gin.Context)
+}
+
+type assignmentControllerImpl struct {
+	service.AssignmentService
+	*validator.Validate
+}
+
+func NewAssignmentController(assignmentService service.AssignmentService, validate *validator.Validate) AssignmentController {
+	return &assignmentControllerImpl{assignmentService, validate}
+}
+
+func (a *assignmentControllerImpl) CheckOut(c *gin.Context) {
+	var checkOutRequest web.AssignmentCheckOutRequest
+	if err := helper.ReadFromRequestBody(c, &checkOutRequest); err != nil {
+		return
+	}
+
+	if err := a.Validate.Struct(&checkOutRequest); err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	username, _ := c.Get("username")
+	assignment, errResponse := a.AssignmentService.CheckOut(c.Request.Context(), checkOutRequest, username.(string))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusCreated, web.NewStatusCreatedData("success check out item", assignment))
+}
+
+func (a *assignmentControllerImpl) CheckIn(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("assignmentID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	var checkInRequest web.AssignmentCheckInRequest
+	if c.Request.ContentLength > 0 {
+		if err := helper.ReadFromRequestBody(c, &checkInRequest); err != nil {
+			return
+		}
+	}
+
+	checkInRequest.ID = id
+	if err := a.Validate.Struct(&checkInRequest); err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	username, _ := c.Get("username")
+	errResponse := a.AssignmentService.CheckIn(c.Request.Context(), checkInRequest, username.(string))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("success check in item"))
+}
+
+func (a *assignmentControllerImpl) GetAll(c *gin.Context) {
+	assignments, errResponse := a.AssignmentService.GetAll(c.Request.Context())
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success get all assignments", assignments))
+}
+
+func (a *assignmentControllerImpl) GetOverdue(c *gin.Context) {
+	assignments, errResponse := a.AssignmentService.GetOverdue(c.Request.Context())
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success get overdue assignments", assignments))
+}
+
+func (a *assignmentControllerImpl) GetByAssignee(c *gin.Context) {
+	assignments, errResponse := a.AssignmentService.GetByAssignee(c.Request.Context(), c.Param("username"))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success get assignments", assignments))
+}
+
+func (a *assignmentControllerImpl) GetMine(c *gin.Context) {
+	username, _ := c.Get("username")
+	assignments, errResponse := a.AssignmentService.GetByAssignee(c.Request.Context(), username.(string))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success get assignments", assignments))
+}
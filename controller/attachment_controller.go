@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"inventory-management-system/helper"
+	"inventory-management-system/model/web"
+	"inventory-management-system/service"
+	"net/http"
+	"strconv"
+)
+
+type AttachmentController interface {
+	Upload(c *gin.Context)
+	Delete(c *gin.Context)
+	GetByItemID(c *gin.Context)
+	Download(c *gin.Context)
+	Thumbnail(c *gin.Context)
+}
+
+type attachmentControllerImpl struct {
+	service.AttachmentService
+	*validator.Validate
+}
+
+func NewAttachmentController(attachmentService service.AttachmentService, validate *validator.Validate) AttachmentController {
+	return &attachmentControllerImpl{attachmentService, validate}
+}
+
+func (a *attachmentControllerImpl) Upload(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Param("itemID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			helper.AbortWithError(c, web.NewRequestEntityTooLargeError(web.CodeFileTooLarge, "file is too large"))
+			return
+		}
+
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeFileRequired, "file is required"))
+		return
+	}
+
+	attachmentUploadRequest := web.AttachmentUploadRequest{
+		ItemID:   itemID,
+		Kind:     c.DefaultPostForm("kind", "other"),
+		FileName: fileHeader.Filename,
+		Size:     fileHeader.Size,
+	}
+	if err := a.Validate.Struct(&attachmentUploadRequest); err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeFileUnreadable, "failed to read file"))
+		return
+	}
+	defer file.Close()
+
+	username, _ := c.Get("username")
+	attachment, errResponse := a.AttachmentService.Upload(c.Request.Context(), attachmentUploadRequest, file, username.(string))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusCreated, web.NewStatusCreatedData("success upload attachment", attachment))
+}
+
+func (a *attachmentControllerImpl) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("attachmentID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	username, _ := c.Get("username")
+	errResponse := a.AttachmentService.Delete(c.Request.Context(), id, username.(string))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("success delete attachment"))
+}
+
+func (a *attachmentControllerImpl) GetByItemID(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Param("itemID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	attachments, errResponse := a.AttachmentService.GetByItemID(c.Request.Context(), itemID)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success get all attachment", attachments))
+}
+
+func (a *attachmentControllerImpl) Download(c *gin.Context) {
+	a.serve(c, false)
+}
+
+func (a *attachmentControllerImpl) Thumbnail(c *gin.Context) {
+	a.serve(c, true)
+}
+
+func (a *attachmentControllerImpl) serve(c *gin.Context, thumbnail bool) {
+	id, err := strconv.Atoi(c.Param("attachmentID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	attachment, errResponse := a.AttachmentService.GetByID(c.Request.Context(), id)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	content, errResponse := a.AttachmentService.Open(c.Request.Context(), attachment, thumbnail)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+	defer content.Close()
+
+	contentType := attachment.ContentType
+	contentLength := attachment.Size
+	if thumbnail {
+		contentType = "image/jpeg"
+		contentLength = -1
+	}
+
+	c.DataFromReader(http.StatusOK, contentLength, contentType, content, map[string]string{
+		"Content-Disposition": fmt.Sprintf("inline; filename=%q", attachment.FileName),
+	})
+}
@@ -16,6 +16,9 @@ type CategoryController interface {
 	Delete(c *gin.Context)
 	GetAll(c *gin.Context)
 	GetByID(c *gin.Context)
+	AddAttribute(c *gin.Context)
+	DeleteAttribute(c *gin.Context)
+	GetAttributes(c *gin.Context)
 }
 
 type categoryControllerImpl struct {
@@ -35,13 +38,13 @@ func (cc *categoryControllerImpl) Add(c *gin.Context) {
 
 	err := cc.Validate.Struct(categoryAddRequest)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("validation error: "+err.Error()))
+		helper.AbortWithError(c, helper.ValidationError(c, err))
 		return
 	}
 
-	errResponse := cc.CategoryService.Add(&categoryAddRequest)
+	errResponse := cc.CategoryService.Add(c.Request.Context(), &categoryAddRequest)
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
@@ -51,7 +54,7 @@ func (cc *categoryControllerImpl) Add(c *gin.Context) {
 func (cc *categoryControllerImpl) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("categoryID"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("invalid id"))
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
 		return
 	}
 
@@ -63,13 +66,13 @@ func (cc *categoryControllerImpl) Update(c *gin.Context) {
 
 	err = cc.Validate.Struct(categoryUpdateRequest)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("validation error: "+err.Error()))
+		helper.AbortWithError(c, helper.ValidationError(c, err))
 		return
 	}
 
-	errResponse := cc.CategoryService.Update(categoryUpdateRequest)
+	errResponse := cc.CategoryService.Update(c.Request.Context(), categoryUpdateRequest)
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
@@ -79,13 +82,13 @@ func (cc *categoryControllerImpl) Update(c *gin.Context) {
 func (cc *categoryControllerImpl) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("categoryID"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("invalid id"))
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
 		return
 	}
 
-	errResponse := cc.CategoryService.Delete(id)
+	errResponse := cc.CategoryService.Delete(c.Request.Context(), id)
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
@@ -93,9 +96,9 @@ func (cc *categoryControllerImpl) Delete(c *gin.Context) {
 }
 
 func (cc *categoryControllerImpl) GetAll(c *gin.Context) {
-	categories, errResponse := cc.CategoryService.GetAll()
+	categories, errResponse := cc.CategoryService.GetAll(c.Request.Context())
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
@@ -105,15 +108,81 @@ func (cc *categoryControllerImpl) GetAll(c *gin.Context) {
 func (cc *categoryControllerImpl) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("categoryID"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("invalid id"))
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
 		return
 	}
 
-	category, errResponse := cc.CategoryService.GetByID(id)
+	category, errResponse := cc.CategoryService.GetByID(c.Request.Context(), id)
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
 	c.JSON(http.StatusOK, web.NewStatusOKData("success get category", category))
 }
+
+func (cc *categoryControllerImpl) AddAttribute(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("categoryID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	var categoryAttributeAddRequest web.CategoryAttributeAddRequest
+	if err := helper.ReadFromRequestBody(c, &categoryAttributeAddRequest); err != nil {
+		return
+	}
+
+	categoryAttributeAddRequest.CategoryID = id
+	err = cc.Validate.Struct(categoryAttributeAddRequest)
+	if err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	attribute, errResponse := cc.CategoryService.AddAttribute(c.Request.Context(), categoryAttributeAddRequest)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusCreated, web.NewStatusCreatedData("success add category attribute", attribute))
+}
+
+func (cc *categoryControllerImpl) DeleteAttribute(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("categoryID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	attributeID, err := strconv.Atoi(c.Param("attributeID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid attribute id"))
+		return
+	}
+
+	errResponse := cc.CategoryService.DeleteAttribute(c.Request.Context(), id, attributeID)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("success delete category attribute"))
+}
+
+func (cc *categoryControllerImpl) GetAttributes(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("categoryID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	attributes, errResponse := cc.CategoryService.GetAttributes(c.Request.Context(), id)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success get category attributes", attributes))
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"inventory-management-system/helper"
+	"inventory-management-system/model/web"
+	"inventory-management-system/service"
+	"net/http"
+)
+
+type HealthController interface {
+	Live(c *gin.Context)
+	Ready(c *gin.Context)
+}
+
+type healthControllerImpl struct {
+	service.HealthService
+}
+
+func NewHealthController(healthService service.HealthService) HealthController {
+	return &healthControllerImpl{healthService}
+}
+
+func (h *healthControllerImpl) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("alive"))
+}
+
+func (h *healthControllerImpl) Ready(c *gin.Context) {
+	errResponse := h.HealthService.Ready(c.Request.Context())
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("ready"))
+}
@@ -34,14 +34,14 @@ func (i *itemControllerImpl) Add(c *gin.Context) {
 	}
 
 	if err := i.Validate.Struct(&itemAddRequest); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("validation error: "+err.Error()))
+		helper.AbortWithError(c, helper.ValidationError(c, err))
 		return
 	}
 
 	username, _ := c.Get("username")
-	errResponse := i.ItemService.Add(itemAddRequest, username.(string))
+	errResponse := i.ItemService.Add(c.Request.Context(), itemAddRequest, username.(string))
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
@@ -51,7 +51,7 @@ func (i *itemControllerImpl) Add(c *gin.Context) {
 func (i *itemControllerImpl) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("itemID"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("invalid id"))
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
 		return
 	}
 
@@ -62,14 +62,14 @@ func (i *itemControllerImpl) Update(c *gin.Context) {
 
 	itemUpdateRequest.ID = id
 	if err := i.Validate.Struct(&itemUpdateRequest); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("validation error: "+err.Error()))
+		helper.AbortWithError(c, helper.ValidationError(c, err))
 		return
 	}
 
 	username, _ := c.Get("username")
-	errResponse := i.ItemService.Update(itemUpdateRequest, username.(string))
+	errResponse := i.ItemService.Update(c.Request.Context(), itemUpdateRequest, username.(string))
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
@@ -79,14 +79,14 @@ func (i *itemControllerImpl) Update(c *gin.Context) {
 func (i *itemControllerImpl) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("itemID"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("invalid id"))
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
 		return
 	}
 
 	username, _ := c.Get("username")
-	errResponse := i.ItemService.Delete(id, username.(string))
+	errResponse := i.ItemService.Delete(c.Request.Context(), id, username.(string))
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
@@ -96,13 +96,13 @@ func (i *itemControllerImpl) Delete(c *gin.Context) {
 func (i *itemControllerImpl) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("itemID"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("invalid id"))
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
 		return
 	}
 
-	item, errResponse := i.ItemService.GetByID(id)
+	item, errResponse := i.ItemService.GetByID(c.Request.Context(), id)
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
@@ -110,9 +110,15 @@ func (i *itemControllerImpl) GetByID(c *gin.Context) {
 }
 
 func (i *itemControllerImpl) GetAll(c *gin.Context) {
-	items, errResponse := i.ItemService.GetAll()
+	filters, errResponse := helper.ParseAttributeFilters(c.QueryArray("attr"))
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	items, errResponse := i.ItemService.GetAll(c.Request.Context(), filters)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
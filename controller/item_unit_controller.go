@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"inventory-management-system/helper"
+	"inventory-management-system/model/web"
+	"inventory-management-system/service"
+	"net/http"
+	"strconv"
+)
+
+type ItemUnitController interface {
+	Add(c *gin.Context)
+	Update(c *gin.Context)
+	GetByID(c *gin.Context)
+	GetByItemID(c *gin.Context)
+	GetBySerialNumber(c *gin.Context)
+	GetByAssetTag(c *gin.Context)
+}
+
+type itemUnitControllerImpl struct {
+	service.ItemUnitService
+	*validator.Validate
+}
+
+func NewItemUnitController(itemUnitService service.ItemUnitService, validate *validator.Validate) ItemUnitController {
+	return &itemUnitControllerImpl{itemUnitService, validate}
+}
+
+func (i *itemUnitControllerImpl) Add(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Param("itemID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	var itemUnitAddRequest web.ItemUnitAddRequest
+	if err := helper.ReadFromRequestBody(c, &itemUnitAddRequest); err != nil {
+		return
+	}
+
+	itemUnitAddRequest.ItemID = itemID
+	if err := i.Validate.Struct(&itemUnitAddRequest); err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	username, _ := c.Get("username")
+	unit, errResponse := i.ItemUnitService.Add(c.Request.Context(), itemUnitAddRequest, username.(string))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusCreated, web.NewStatusCreatedData("success add unit", unit))
+}
+
+func (i *itemUnitControllerImpl) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("unitID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	var itemUnitUpdateRequest web.ItemUnitUpdateRequest
+	if err := helper.ReadFromRequestBody(c, &itemUnitUpdateRequest); err != nil {
+		return
+	}
+
+	itemUnitUpdateRequest.ID = id
+	if err := i.Validate.Struct(&itemUnitUpdateRequest); err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	username, _ := c.Get("username")
+	errResponse := i.ItemUnitService.Update(c.Request.Context(), itemUnitUpdateRequest, username.(string))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("success update unit"))
+}
+
+func (i *itemUnitControllerImpl) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("unitID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	unit, errResponse := i.ItemUnitService.GetByID(c.Request.Context(), id)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success get unit", unit))
+}
+
+func (i *itemUnitControllerImpl) GetByItemID(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Param("itemID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	units, errResponse := i.ItemUnitService.GetByItemID(c.Request.Context(), itemID)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success get all unit", units))
+}
+
+func (i *itemUnitControllerImpl) GetBySerialNumber(c *gin.Context) {
+	unit, errResponse := i.ItemUnitService.GetBySerialNumber(c.Request.Context(), c.Param("serialNumber"))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success get unit", unit))
+}
+
+func (i *itemUnitControllerImpl) GetByAssetTag(c *gin.Context) {
+	unit, errResponse := i.ItemUnitService.GetByAssetTag(c.Request.Context(), c.Param("assetTag"))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success get unit", unit))
+}
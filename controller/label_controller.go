@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"inventory-management-system/helper"
+	"inventory-management-system/label"
+	"inventory-management-system/logging"
+	"inventory-management-system/model/web"
+	"inventory-management-system/service"
+	"net/http"
+	"strconv"
+)
+
+type LabelController interface {
+	ItemLabel(c *gin.Context)
+	UnitLabel(c *gin.Context)
+	BatchLabels(c *gin.Context)
+	Scan(c *gin.Context)
+}
+
+type labelControllerImpl struct {
+	service.LabelService
+	*validator.Validate
+}
+
+func NewLabelController(labelService service.LabelService, validate *validator.Validate) LabelController {
+	return &labelControllerImpl{labelService, validate}
+}
+
+func (l *labelControllerImpl) ItemLabel(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("itemID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	itemLabel, errResponse := l.LabelService.ItemLabel(c.Request.Context(), id)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	writeLabels(c, c.DefaultQuery("format", "png"), fmt.Sprintf("item-%d", id), itemLabel)
+}
+
+func (l *labelControllerImpl) UnitLabel(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("unitID"))
+	if err != nil {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidID, "invalid id"))
+		return
+	}
+
+	unitLabel, errResponse := l.LabelService.UnitLabel(c.Request.Context(), id)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	writeLabels(c, c.DefaultQuery("format", "png"), fmt.Sprintf("unit-%d", id), unitLabel)
+}
+
+func (l *labelControllerImpl) BatchLabels(c *gin.Context) {
+	var labelBatchRequest web.LabelBatchRequest
+	if err := helper.ReadFromRequestBody(c, &labelBatchRequest); err != nil {
+		return
+	}
+
+	if err := l.Validate.Struct(&labelBatchRequest); err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	labels, errResponse := l.LabelService.BatchLabels(c.Request.Context(), labelBatchRequest)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	writeLabels(c, "pdf", "labels", labels...)
+}
+
+func (l *labelControllerImpl) Scan(c *gin.Context) {
+	result, errResponse := l.LabelService.Resolve(c.Request.Context(), c.Param("code"))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success resolve code", result))
+}
+
+func writeLabels(c *gin.Context, format string, fileName string, labels ...label.Label) {
+	buf := bytes.Buffer{}
+	var contentType string
+	var err error
+	switch format {
+	case "png":
+		if len(labels) != 1 {
+			helper.AbortWithError(c, web.NewBadRequestError(web.CodeLabelFormatInvalid, "png format supports a single label only"))
+			return
+		}
+		contentType = "image/png"
+		err = label.WritePNG(&buf, labels[0])
+	case "pdf":
+		contentType = "application/pdf"
+		err = label.WritePDF(&buf, labels)
+	default:
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeLabelFormatInvalid, "invalid label format"))
+		return
+	}
+
+	var codeErr *label.CodeError
+	if errors.As(err, &codeErr) {
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeLabelCodeInvalid, "code {0} cannot be printed as a barcode", codeErr.Code))
+		return
+	}
+	if err != nil {
+		logging.FromContext(c.Request.Context()).ErrorContext(c.Request.Context(), "failed to render labels", "error", err)
+		helper.AbortWithError(c, web.NewInternalServerErrorError())
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", fileName+"."+format))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"github.com/gin-gonic/gin"
+	"inventory-management-system/config"
+	"inventory-management-system/helper"
+	"inventory-management-system/metrics"
+	"inventory-management-system/model/web"
+	"inventory-management-system/service"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const oidcCookie = "oidc_auth"
+
+type OIDCController interface {
+	Login(c *gin.Context)
+	Callback(c *gin.Context)
+}
+
+type oidcControllerImpl struct {
+	service.OIDCService
+	metrics.Metrics
+	cookies           config.Cookie
+	postLoginRedirect string
+}
+
+func NewOIDCController(oidcService service.OIDCService, recorder metrics.Metrics, cookies config.Cookie, postLoginRedirect string) OIDCController {
+	return &oidcControllerImpl{oidcService, recorder, cookies, postLoginRedirect}
+}
+
+func (o *oidcControllerImpl) Login(c *gin.Context) {
+	authRequest, errResponse := o.OIDCService.Begin(c.Request.Context())
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	value := strings.Join([]string{authRequest.State, authRequest.Nonce, authRequest.Verifier}, ".")
+	http.SetCookie(c.Writer, o.stateCookie(value, time.Now().Add(10*time.Minute)))
+	c.Redirect(http.StatusFound, authRequest.URL)
+}
+
+func (o *oidcControllerImpl) Callback(c *gin.Context) {
+	if idpError := c.Query("error"); idpError != "" {
+		o.Metrics.ObserveLogin(false)
+		helper.AbortWithError(c, web.NewUnauthorizedError(web.CodeSSOLoginFailed, "sso login failed: {0}", idpError))
+		return
+	}
+
+	value, _ := c.Cookie(oidcCookie)
+	http.SetCookie(c.Writer, o.stateCookie("", time.Unix(0, 0)))
+
+	parts := strings.Split(value, ".")
+	state := c.Query("state")
+	if len(parts) != 3 || state == "" || subtle.ConstantTimeCompare([]byte(parts[0]), []byte(state)) != 1 {
+		o.Metrics.ObserveLogin(false)
+		helper.AbortWithError(c, web.NewUnauthorizedError(web.CodeSSOStateInvalid, "invalid sso state"))
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		o.Metrics.ObserveLogin(false)
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeAuthorizationCodeMissing, "authorization code is empty"))
+		return
+	}
+
+	tokens, challenge, errResponse := o.OIDCService.Callback(c.Request.Context(), code, parts[2], parts[1], c.ClientIP())
+	if challenge != nil {
+		c.JSON(http.StatusOK, web.NewStatusOKData("two-factor authentication required", challenge))
+		return
+	}
+
+	o.Metrics.ObserveLogin(errResponse == nil)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	if o.cookies.Enabled && o.postLoginRedirect != "" {
+		setTokenCookies(c, o.cookies, tokens)
+		c.Redirect(http.StatusFound, o.postLoginRedirect)
+		return
+	}
+
+	writeTokens(c, o.cookies, web.TokenDeliveryCookie, "login user success", tokens)
+}
+
+func (o *oidcControllerImpl) stateCookie(value string, expires time.Time) *http.Cookie {
+	cookie := newCookie(o.cookies, oidcCookie, value, "/api/v1/oidc", expires, true)
+	if cookie.SameSite == http.SameSiteStrictMode {
+		cookie.SameSite = http.SameSiteLaxMode
+	}
+
+	return cookie
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"inventory-management-system/helper"
+	"inventory-management-system/model/web"
+	"inventory-management-system/service"
+	"net/http"
+)
+
+type PasswordResetController interface {
+	Request(c *gin.Context)
+	Confirm(c *gin.Context)
+}
+
+type passwordResetControllerImpl struct {
+	service.PasswordResetService
+	*validator.Validate
+}
+
+func NewPasswordResetController(passwordResetService service.PasswordResetService, validate *validator.Validate) PasswordResetController {
+	return &passwordResetControllerImpl{passwordResetService, validate}
+}
+
+func (p *passwordResetControllerImpl) Request(c *gin.Context) {
+	var passwordResetRequest web.PasswordResetRequest
+	if err := helper.ReadFromRequestBody(c, &passwordResetRequest); err != nil {
+		return
+	}
+
+	err := p.Validate.Struct(passwordResetRequest)
+	if err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	errResponse := p.PasswordResetService.Request(c.Request.Context(), passwordResetRequest.Email)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("if the email belongs to an account, a reset link has been sent"))
+}
+
+func (p *passwordResetControllerImpl) Confirm(c *gin.Context) {
+	var passwordResetConfirmRequest web.PasswordResetConfirmRequest
+	if err := helper.ReadFromRequestBody(c, &passwordResetConfirmRequest); err != nil {
+		return
+	}
+
+	err := p.Validate.Struct(passwordResetConfirmRequest)
+	if err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	errResponse := p.PasswordResetService.Confirm(c.Request.Context(), passwordResetConfirmRequest)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("reset password success"))
+}
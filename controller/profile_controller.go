@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"inventory-management-system/config"
+	"inventory-management-system/helper"
+	"inventory-management-system/model/web"
+	"inventory-management-system/service"
+	"net/http"
+)
+
+type ProfileController interface {
+	Get(c *gin.Context)
+	Update(c *gin.Context)
+	ChangePassword(c *gin.Context)
+}
+
+type profileControllerImpl struct {
+	service.UserService
+	*validator.Validate
+	cookies config.Cookie
+}
+
+func NewProfileController(userService service.UserService, validate *validator.Validate, cookies config.Cookie) ProfileController {
+	return &profileControllerImpl{userService, validate, cookies}
+}
+
+func (p *profileControllerImpl) Get(c *gin.Context) {
+	user, errResponse := p.UserService.GetByUsername(c.Request.Context(), c.GetString("username"))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("get profile success", user))
+}
+
+func (p *profileControllerImpl) Update(c *gin.Context) {
+	var profileUpdateRequest web.ProfileUpdateRequest
+	if err := helper.ReadFromRequestBody(c, &profileUpdateRequest); err != nil {
+		return
+	}
+
+	err := p.Validate.Struct(profileUpdateRequest)
+	if err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	errResponse := p.UserService.UpdateProfile(c.Request.Context(), c.GetString("username"), profileUpdateRequest)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("update profile success"))
+}
+
+func (p *profileControllerImpl) ChangePassword(c *gin.Context) {
+	var passwordChangeRequest web.PasswordChangeRequest
+	if err := helper.ReadFromRequestBody(c, &passwordChangeRequest); err != nil {
+		return
+	}
+
+	err := p.Validate.Struct(passwordChangeRequest)
+	if err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	tokens, errResponse := p.UserService.ChangePassword(c.Request.Context(), c.GetString("username"), passwordChangeRequest)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	writeTokens(c, p.cookies, tokenDelivery(c, passwordChangeRequest.TokenDelivery), "change password success", tokens)
+}
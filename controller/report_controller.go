@@ -2,6 +2,7 @@ package controller
 
 import (
 	"github.com/gin-gonic/gin"
+	"inventory-management-system/helper"
 	"inventory-management-system/model/domain"
 	"inventory-management-system/model/web"
 	"inventory-management-system/service"
@@ -23,9 +24,9 @@ func NewReportController(reportService service.ReportService) ReportController {
 }
 
 func (r *reportControllerImpl) GetAllActivity(c *gin.Context) {
-	activities, errResponse := r.ReportService.GetAllActivity()
+	activities, errResponse := r.ReportService.GetAllActivity(c.Request.Context())
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
@@ -35,13 +36,13 @@ func (r *reportControllerImpl) GetAllActivity(c *gin.Context) {
 func (r *reportControllerImpl) ReportStock(c *gin.Context) {
 	totalStock, err := strconv.Atoi(c.Param("itemStock"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("invalid item stock"))
+		helper.AbortWithError(c, web.NewBadRequestError(web.CodeInvalidQuery, "invalid item stock"))
 		return
 	}
 
-	items, errResponse := r.ReportService.ReportStock(totalStock)
+	items, errResponse := r.ReportService.ReportStock(c.Request.Context(), totalStock)
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
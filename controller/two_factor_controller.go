@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"inventory-management-system/helper"
+	"inventory-management-system/model/web"
+	"inventory-management-system/service"
+	"net/http"
+)
+
+type TwoFactorController interface {
+	Enroll(c *gin.Context)
+	Confirm(c *gin.Context)
+	Disable(c *gin.Context)
+	Reset(c *gin.Context)
+}
+
+type twoFactorControllerImpl struct {
+	service.TwoFactorService
+	*validator.Validate
+}
+
+func NewTwoFactorController(twoFactorService service.TwoFactorService, validate *validator.Validate) TwoFactorController {
+	return &twoFactorControllerImpl{twoFactorService, validate}
+}
+
+func (t *twoFactorControllerImpl) Enroll(c *gin.Context) {
+	enrollment, errResponse := t.TwoFactorService.Enroll(c.Request.Context(), c.GetString("username"))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("two-factor enrollment started", enrollment))
+}
+
+func (t *twoFactorControllerImpl) Confirm(c *gin.Context) {
+	var totpCodeRequest web.TOTPCodeRequest
+	if err := helper.ReadFromRequestBody(c, &totpCodeRequest); err != nil {
+		return
+	}
+
+	err := t.Validate.Struct(totpCodeRequest)
+	if err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	recoveryCodes, errResponse := t.TwoFactorService.Confirm(c.Request.Context(), c.GetString("username"), totpCodeRequest.Code)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("two-factor authentication enabled", gin.H{"recovery_codes": recoveryCodes}))
+}
+
+func (t *twoFactorControllerImpl) Disable(c *gin.Context) {
+	var totpCodeRequest web.TOTPCodeRequest
+	if err := helper.ReadFromRequestBody(c, &totpCodeRequest); err != nil {
+		return
+	}
+
+	err := t.Validate.Struct(totpCodeRequest)
+	if err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	errResponse := t.TwoFactorService.Disable(c.Request.Context(), c.GetString("username"), totpCodeRequest.Code)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("two-factor authentication disabled"))
+}
+
+func (t *twoFactorControllerImpl) Reset(c *gin.Context) {
+	errResponse := t.TwoFactorService.Reset(c.Request.Context(), c.Param("username"))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("reset two-factor authentication success"))
+}
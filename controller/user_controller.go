@@ -3,29 +3,42 @@ package controller
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"inventory-management-system/config"
 	"inventory-management-system/helper"
+	"inventory-management-system/metrics"
+	"inventory-management-system/model/domain"
 	"inventory-management-system/model/web"
 	"inventory-management-system/service"
 	"net/http"
+	"strings"
 	"time"
 )
 
 type UserController interface {
 	Register(c *gin.Context)
 	Login(c *gin.Context)
+	EnrollMFA(c *gin.Context)
+	VerifyMFA(c *gin.Context)
 	Update(c *gin.Context)
 	Delete(c *gin.Context)
+	Deactivate(c *gin.Context)
+	Reactivate(c *gin.Context)
 	GetAll(c *gin.Context)
 	GetByUsername(c *gin.Context)
+	GetLoginAttempts(c *gin.Context)
+	Refresh(c *gin.Context)
+	Logout(c *gin.Context)
 }
 
 type userControllerImpl struct {
 	service.UserService
 	*validator.Validate
+	metrics.Metrics
+	cookies config.Cookie
 }
 
-func NewUserController(userService service.UserService, validate *validator.Validate) UserController {
-	return &userControllerImpl{userService, validate}
+func NewUserController(userService service.UserService, validate *validator.Validate, recorder metrics.Metrics, cookies config.Cookie) UserController {
+	return &userControllerImpl{userService, validate, recorder, cookies}
 }
 
 func (u *userControllerImpl) Register(c *gin.Context) {
@@ -36,13 +49,13 @@ func (u *userControllerImpl) Register(c *gin.Context) {
 
 	err := u.Validate.Struct(userRegisterRequest)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("validation error: "+err.Error()))
+		helper.AbortWithError(c, helper.ValidationError(c, err))
 		return
 	}
 
-	errResponse := u.UserService.Register(&userRegisterRequest)
+	errResponse := u.UserService.Register(c.Request.Context(), &userRegisterRequest)
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
@@ -57,23 +70,107 @@ func (u *userControllerImpl) Login(c *gin.Context) {
 
 	err := u.Validate.Struct(userLoginRequest)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("validation error: "+err.Error()))
+		helper.AbortWithError(c, helper.ValidationError(c, err))
 		return
 	}
 
-	tokenString, errResponse := u.UserService.Login(&userLoginRequest)
+	tokens, challenge, errResponse := u.UserService.Login(c.Request.Context(), &userLoginRequest, c.ClientIP())
+	if challenge != nil {
+		c.JSON(http.StatusOK, web.NewStatusOKData("two-factor authentication required", challenge))
+		return
+	}
+
+	u.Metrics.ObserveLogin(errResponse == nil)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	writeTokens(c, u.cookies, tokenDelivery(c, userLoginRequest.TokenDelivery), "login user success", tokens)
+}
+
+func (u *userControllerImpl) EnrollMFA(c *gin.Context) {
+	var mfaEnrollRequest web.MFAEnrollRequest
+	if err := helper.ReadFromRequestBody(c, &mfaEnrollRequest); err != nil {
+		return
+	}
+
+	err := u.Validate.Struct(mfaEnrollRequest)
+	if err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	enrollment, errResponse := u.UserService.EnrollMFA(c.Request.Context(), mfaEnrollRequest.MFAToken)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("two-factor enrollment started", enrollment))
+}
+
+func (u *userControllerImpl) VerifyMFA(c *gin.Context) {
+	var mfaVerifyRequest web.MFAVerifyRequest
+	if err := helper.ReadFromRequestBody(c, &mfaVerifyRequest); err != nil {
+		return
+	}
+
+	err := u.Validate.Struct(mfaVerifyRequest)
+	if err != nil {
+		helper.AbortWithError(c, helper.ValidationError(c, err))
+		return
+	}
+
+	tokens, errResponse := u.UserService.VerifyMFA(c.Request.Context(), mfaVerifyRequest, c.ClientIP())
+	u.Metrics.ObserveLogin(errResponse == nil)
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
-	http.SetCookie(c.Writer, &http.Cookie{
-		Name:    "session_token",
-		Value:   *tokenString,
-		Expires: time.Now().Add(24 * time.Hour),
-	})
+	writeTokens(c, u.cookies, tokenDelivery(c, mfaVerifyRequest.TokenDelivery), "login user success", tokens)
+}
+
+func (u *userControllerImpl) Refresh(c *gin.Context) {
+	var tokenRefreshRequest web.TokenRefreshRequest
+	if c.Request.ContentLength > 0 {
+		if err := helper.ReadFromRequestBody(c, &tokenRefreshRequest); err != nil {
+			return
+		}
+	}
 
-	c.JSON(http.StatusOK, web.NewStatusOKMessage("login user success"))
+	delivery := web.TokenDeliveryBody
+	if tokenRefreshRequest.RefreshToken == "" {
+		tokenRefreshRequest.RefreshToken, _ = c.Cookie("refresh_token")
+		delivery = web.TokenDeliveryCookie
+	}
+
+	if tokenRefreshRequest.RefreshToken == "" {
+		helper.AbortWithError(c, web.NewUnauthorizedError(web.CodeRefreshTokenMissing, "refresh token is empty"))
+		return
+	}
+
+	tokens, errResponse := u.UserService.Refresh(c.Request.Context(), tokenRefreshRequest.RefreshToken)
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	writeTokens(c, u.cookies, delivery, "refresh token success", tokens)
+}
+
+func (u *userControllerImpl) Logout(c *gin.Context) {
+	errResponse := u.UserService.Logout(c.Request.Context(), c.GetString("session_token"))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	if u.cookies.Enabled {
+		clearTokenCookies(c, u.cookies)
+	}
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("logout user success"))
 }
 
 func (u *userControllerImpl) Update(c *gin.Context) {
@@ -85,13 +182,13 @@ func (u *userControllerImpl) Update(c *gin.Context) {
 
 	err := u.Validate.Struct(userUpdateRequest)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("validation error: "+err.Error()))
+		helper.AbortWithError(c, helper.ValidationError(c, err))
 		return
 	}
 
-	errResponse := u.UserService.Update(userUpdateRequest)
+	errResponse := u.UserService.Update(c.Request.Context(), userUpdateRequest)
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
@@ -100,19 +197,39 @@ func (u *userControllerImpl) Update(c *gin.Context) {
 
 func (u *userControllerImpl) Delete(c *gin.Context) {
 	username := c.Param("username")
-	errResponse := u.UserService.Delete(username)
+	errResponse := u.UserService.Delete(c.Request.Context(), username, c.GetString("username"))
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
 	c.JSON(http.StatusOK, web.NewStatusOKMessage("delete user success"))
 }
 
+func (u *userControllerImpl) Deactivate(c *gin.Context) {
+	errResponse := u.UserService.Deactivate(c.Request.Context(), c.Param("username"), c.GetString("username"))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("deactivate user success"))
+}
+
+func (u *userControllerImpl) Reactivate(c *gin.Context) {
+	errResponse := u.UserService.Reactivate(c.Request.Context(), c.Param("username"))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKMessage("reactivate user success"))
+}
+
 func (u *userControllerImpl) GetAll(c *gin.Context) {
-	users, errResponse := u.UserService.GetAll()
+	users, errResponse := u.UserService.GetAll(c.Request.Context())
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
@@ -121,11 +238,75 @@ func (u *userControllerImpl) GetAll(c *gin.Context) {
 
 func (u *userControllerImpl) GetByUsername(c *gin.Context) {
 	username := c.Param("username")
-	user, errResponse := u.UserService.GetByUsername(username)
+	user, errResponse := u.UserService.GetByUsername(c.Request.Context(), username)
 	if errResponse != nil {
-		c.AbortWithStatusJSON(errResponse.Code(), errResponse)
+		helper.AbortWithError(c, errResponse)
 		return
 	}
 
 	c.JSON(http.StatusOK, web.NewStatusOKData("get user success", user))
 }
+
+func (u *userControllerImpl) GetLoginAttempts(c *gin.Context) {
+	attempts, errResponse := u.UserService.GetLoginAttempts(c.Request.Context(), c.Query("username"))
+	if errResponse != nil {
+		helper.AbortWithError(c, errResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData("success get all login attempts", attempts))
+}
+
+func tokenDelivery(c *gin.Context, requested string) string {
+	if requested == "" && c.GetHeader("Authorization") != "" {
+		return web.TokenDeliveryBody
+	}
+
+	return requested
+}
+
+func writeTokens(c *gin.Context, cookies config.Cookie, delivery string, message string, tokens domain.TokenPair) {
+	if cookies.Enabled && delivery != web.TokenDeliveryBody {
+		setTokenCookies(c, cookies, tokens)
+		tokens.AccessToken = ""
+		tokens.RefreshToken = ""
+	} else {
+		tokens.CSRFToken = ""
+	}
+
+	c.JSON(http.StatusOK, web.NewStatusOKData(message, tokens))
+}
+
+func setTokenCookies(c *gin.Context, cookies config.Cookie, tokens domain.TokenPair) {
+	http.SetCookie(c.Writer, newCookie(cookies, "session_token", tokens.AccessToken, "/", tokens.ExpiresAt, true))
+	http.SetCookie(c.Writer, newCookie(cookies, "refresh_token", tokens.RefreshToken, "/api/v1/token", tokens.RefreshExpiresAt, true))
+	http.SetCookie(c.Writer, newCookie(cookies, "csrf_token", tokens.CSRFToken, "/", tokens.RefreshExpiresAt, false))
+}
+
+func clearTokenCookies(c *gin.Context, cookies config.Cookie) {
+	expired := time.Unix(0, 0)
+	http.SetCookie(c.Writer, newCookie(cookies, "session_token", "", "/", expired, true))
+	http.SetCookie(c.Writer, newCookie(cookies, "refresh_token", "", "/api/v1/token", expired, true))
+	http.SetCookie(c.Writer, newCookie(cookies, "csrf_token", "", "/", expired, false))
+}
+
+func newCookie(cookies config.Cookie, name string, value string, path string, expires time.Time, httpOnly bool) *http.Cookie {
+	sameSite := http.SameSiteLaxMode
+	switch strings.ToLower(cookies.SameSite) {
+	case "strict":
+		sameSite = http.SameSiteStrictMode
+	case "none":
+		sameSite = http.SameSiteNoneMode
+	}
+
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   cookies.Domain,
+		Expires:  expires,
+		Secure:   cookies.Secure,
+		HttpOnly: httpOnly,
+		SameSite: sameSite,
+	}
+}
@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var attributeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var attributeOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+func IsValidAttributeName(name string) bool {
+	return attributeNamePattern.MatchString(name)
+}
+
+func ParseAttributeFilters(expressions []string) ([]domain.AttributeFilter, web.ErrorResponse) {
+	filters := make([]domain.AttributeFilter, 0, len(expressions))
+	for _, expression := range expressions {
+		filter, errResponse := parseAttributeFilter(expression)
+		if errResponse != nil {
+			return nil, errResponse
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+func parseAttributeFilter(expression string) (domain.AttributeFilter, web.ErrorResponse) {
+	index := strings.IndexAny(expression, "<>!=")
+	if index <= 0 {
+		return domain.AttributeFilter{}, web.NewBadRequestError(web.CodeInvalidQuery, "invalid attribute filter {0}", strconv.Quote(expression))
+	}
+
+	name := strings.TrimSpace(expression[:index])
+	if !IsValidAttributeName(name) {
+		return domain.AttributeFilter{}, web.NewBadRequestError(web.CodeInvalidQuery, "invalid attribute name {0}", strconv.Quote(name))
+	}
+
+	for _, operator := range attributeOperators {
+		if !strings.HasPrefix(expression[index:], operator) {
+			continue
+		}
+
+		value := strings.TrimSpace(expression[index+len(operator):])
+		if value == "" {
+			return domain.AttributeFilter{}, web.NewBadRequestError(web.CodeInvalidQuery, "attribute filter {0} has no value", strconv.Quote(expression))
+		}
+
+		if operator != "=" && operator != "!=" {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return domain.AttributeFilter{}, web.NewBadRequestError(web.CodeInvalidQuery, "attribute filter {0} needs a numeric value", strconv.Quote(expression))
+			}
+		}
+
+		return domain.AttributeFilter{Name: name, Operator: operator, Value: value}, nil
+	}
+
+	return domain.AttributeFilter{}, web.NewBadRequestError(web.CodeInvalidQuery, "invalid attribute filter {0}", strconv.Quote(expression))
+}
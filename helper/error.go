@@ -0,0 +1,78 @@
+package helper
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"inventory-management-system/i18n"
+	"inventory-management-system/model/web"
+	"reflect"
+	"strings"
+)
+
+const ProblemContentType = "application/problem+json"
+
+func Translator(c *gin.Context) ut.Translator {
+	trans, _ := c.Get("translator")
+	translator, _ := trans.(ut.Translator)
+	return translator
+}
+
+func AbortWithError(c *gin.Context, errResponse web.ErrorResponse) {
+	trans := Translator(c)
+	detail := i18n.Translate(trans, errResponse.MessageKey(), errResponse.Params()...)
+
+	c.Header("Content-Type", ProblemContentType)
+	if trans != nil {
+		c.Header("Content-Language", trans.Locale())
+	}
+	c.AbortWithStatusJSON(errResponse.Code(), web.NewProblem(errResponse, detail, c.Request.URL.Path))
+}
+
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return validate
+}
+
+func ValidationError(c *gin.Context, err error) web.ErrorResponse {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return web.NewBadRequestError(web.CodeValidationFailed, "validation error")
+	}
+
+	trans := Translator(c)
+	fields := make([]web.FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		message := fieldError.Error()
+		if trans != nil {
+			message = fieldError.Translate(trans)
+		}
+
+		fields = append(fields, web.FieldError{
+			Field:   fieldPath(fieldError),
+			Code:    fieldError.Tag(),
+			Message: message,
+		})
+	}
+
+	return web.NewValidationError(fields)
+}
+
+func fieldPath(fieldError validator.FieldError) string {
+	_, path, found := strings.Cut(fieldError.Namespace(), ".")
+	if !found {
+		return fieldError.Field()
+	}
+	return path
+}
@@ -1,12 +1,15 @@
 package helper
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
 	"inventory-management-system/model/domain"
 	"inventory-management-system/model/web"
+	"inventory-management-system/password"
 	"inventory-management-system/repository"
-	"net/http"
 )
 
 func HashPassword(password string) (string, error) {
@@ -25,25 +28,32 @@ func CheckPasswordHash(password string, hash string) bool {
 func ReadFromRequestBody(c *gin.Context, v any) error {
 	err := c.ShouldBindJSON(v)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("invalid body request"))
+		AbortWithError(c, web.NewBadRequestError(web.CodeInvalidBody, "invalid body request"))
 		return err
 	}
 	return nil
 }
 
-func RegisterAdmin(handleRepository repository.HandlerRepository) {
-	pwd, err := HashPassword("admin123")
+func RegisterAdmin(ctx context.Context, userRepository repository.UserRepository, passwordPolicy password.Policy, adminPassword string) error {
+	if err := passwordPolicy.Check(adminPassword); err != nil {
+		return fmt.Errorf("admin password: %w", err)
+	}
+
+	hashedPassword, err := HashPassword(adminPassword)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	err = handleRepository.Add(&domain.Users{
+	err = userRepository.Create(ctx, &domain.Users{
 		FullName: "Administrator",
 		Username: "administrator",
-		Password: pwd,
+		Password: hashedPassword,
 		Role:     "admin",
+		Active:   true,
 	})
-	if err != nil {
-		panic(err)
+	if errors.Is(err, repository.ErrDuplicate) {
+		return nil
 	}
+
+	return err
 }
@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	idtranslations "github.com/go-playground/validator/v10/translations/id"
+	"inventory-management-system/model/web"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func New(validate *validator.Validate) (*ut.UniversalTranslator, error) {
+	universal := ut.New(en.New(), en.New(), id.New())
+
+	english, _ := universal.GetTranslator("en")
+	if err := entranslations.RegisterDefaultTranslations(validate, english); err != nil {
+		return nil, err
+	}
+
+	bahasa, _ := universal.GetTranslator("id")
+	if err := idtranslations.RegisterDefaultTranslations(validate, bahasa); err != nil {
+		return nil, err
+	}
+
+	for tag, text := range indonesianValidation {
+		register := func(trans ut.Translator) error {
+			return trans.Add(tag, text, true)
+		}
+		if err := validate.RegisterTranslation(tag, bahasa, register, translateField); err != nil {
+			return nil, err
+		}
+	}
+
+	for key, text := range indonesian {
+		if err := bahasa.Add(key, text, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return universal, nil
+}
+
+func translateField(trans ut.Translator, fieldError validator.FieldError) string {
+	text, err := trans.T(fieldError.Tag(), fieldError.Field())
+	if err != nil {
+		return fieldError.Error()
+	}
+	return text
+}
+
+func Match(universal *ut.UniversalTranslator, acceptLanguage string) ut.Translator {
+	trans, _ := universal.FindTranslator(Preferred(acceptLanguage)...)
+	return trans
+}
+
+// Preferred lists the base languages of an Accept-Language header, most preferred first.
+func Preferred(acceptLanguage string) []string {
+	type language struct {
+		name    string
+		quality float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if name == "" || name == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		languages = append(languages, language{name, quality})
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	names := make([]string, 0, len(languages))
+	for _, language := range languages {
+		names = append(names, language.name)
+	}
+	return names
+}
+
+func Translate(trans ut.Translator, key string, params ...string) string {
+	if trans != nil {
+		if text, err := trans.T(key, params...); err == nil {
+			return text
+		}
+	}
+	return web.FormatMessage(key, params)
+}
@@ -0,0 +1,142 @@
+package i18n
+
+var indonesian = map[string]string{
+	"internal server error":     "terjadi kesalahan pada server",
+	"request deadline exceeded": "batas waktu permintaan terlampaui",
+	"database is unreachable":   "database tidak dapat dijangkau",
+	"validation error":          "validasi gagal",
+	"invalid body request":      "isi permintaan tidak valid",
+	"invalid id":                "id tidak valid",
+	"invalid attribute id":      "id atribut tidak valid",
+	"invalid item stock":        "filter stok barang tidak valid",
+
+	"session token is empty":                   "token sesi kosong",
+	"token is expired":                         "token sudah kedaluwarsa",
+	"token is invalid":                         "token tidak valid",
+	"token is revoked":                         "token sudah dicabut",
+	"csrf token is invalid":                    "token csrf tidak valid",
+	"user is not admin":                        "pengguna bukan admin",
+	"too many requests, try again later":       "terlalu banyak permintaan, coba lagi nanti",
+	"refresh token is empty":                   "refresh token kosong",
+	"invalid refresh token":                    "refresh token tidak valid",
+	"refresh token expired":                    "refresh token sudah kedaluwarsa",
+	"refresh token reuse detected":             "refresh token terdeteksi dipakai ulang",
+	"invalid username or password":             "username atau password salah",
+	"too many login attempts, try again later": "terlalu banyak percobaan login, coba lagi nanti",
+	"account is deactivated":                   "akun telah dinonaktifkan",
+	"api key is invalid":                       "api key tidak valid",
+	"api key is expired":                       "api key sudah kedaluwarsa",
+	"api key is revoked":                       "api key sudah dicabut",
+	"api key is missing scope {0}":             "api key tidak memiliki scope {0}",
+	"api key id not found":                     "id api key tidak ditemukan",
+	"api key not found":                        "api key tidak ditemukan",
+	"api key is already revoked":               "api key sudah dicabut sebelumnya",
+	"unknown scope {0}":                        "scope {0} tidak dikenal",
+	"expires_at must be in the future":         "expires_at harus berada di masa depan",
+	"sso login failed":                         "login sso gagal",
+	"sso login failed: {0}":                    "login sso gagal: {0}",
+	"invalid sso state":                        "state sso tidak valid",
+	"authorization code is empty":              "kode otorisasi kosong",
+	"identity is not allowed to sign in":       "identitas ini tidak diizinkan masuk",
+	"account is linked to another identity":    "akun sudah terhubung dengan identitas lain",
+
+	"invalid mfa token":                                  "token mfa tidak valid",
+	"invalid two-factor code":                            "kode autentikasi dua faktor tidak valid",
+	"two-factor authentication is already enabled":       "autentikasi dua faktor sudah aktif",
+	"two-factor authentication is not enabled":           "autentikasi dua faktor belum aktif",
+	"two-factor authentication is required for role {0}": "autentikasi dua faktor wajib untuk peran {0}",
+	"two-factor enrollment has not been started":         "pendaftaran autentikasi dua faktor belum dimulai",
+	"two-factor enrollment is required":                  "pendaftaran autentikasi dua faktor wajib dilakukan",
+
+	"password is too common":                   "password terlalu umum",
+	"password must be at least {0} characters": "password minimal {0} karakter",
+	"password must be at most {0} bytes":       "password maksimal {0} byte",
+	"password must mix at least {0} of lowercase letters, uppercase letters, digits and symbols": "password harus memadukan minimal {0} dari huruf kecil, huruf besar, angka, dan simbol",
+	"current password is incorrect":                            "password saat ini salah",
+	"new password must be different from the current password": "password baru harus berbeda dari password saat ini",
+	"reset token is invalid or expired":                        "token reset tidak valid atau sudah kedaluwarsa",
+
+	"user not found":                                    "pengguna tidak ditemukan",
+	"username is already taken":                         "username sudah dipakai",
+	"email is already in use":                           "email sudah dipakai",
+	"user is already active":                            "pengguna sudah aktif",
+	"user is already deactivated":                       "pengguna sudah dinonaktifkan",
+	"user has recorded activity, deactivate it instead": "pengguna memiliki riwayat aktivitas, nonaktifkan saja",
+	"cannot delete your own account":                    "tidak dapat menghapus akun sendiri",
+	"cannot deactivate your own account":                "tidak dapat menonaktifkan akun sendiri",
+	"cannot delete the last active admin":               "tidak dapat menghapus admin aktif terakhir",
+	"cannot deactivate the last active admin":           "tidak dapat menonaktifkan admin aktif terakhir",
+	"cannot demote the last active admin":               "tidak dapat menurunkan peran admin aktif terakhir",
+	"login attempt not found":                           "percobaan login tidak ditemukan",
+
+	"category not found":               "kategori tidak ditemukan",
+	"category id not found":            "id kategori tidak ditemukan",
+	"category id not exists":           "id kategori tidak ada",
+	"category already exists":          "kategori sudah ada",
+	"category name exists":             "nama kategori sudah ada",
+	"attribute not found":              "atribut tidak ditemukan",
+	"attribute id not found":           "id atribut tidak ditemukan",
+	"attribute name is already in use": "nama atribut sudah dipakai",
+	"attribute name must be lowercase letters, digits and underscores": "nama atribut hanya boleh berisi huruf kecil, angka, dan garis bawah",
+	"attribute {0} is required":                                        "atribut {0} wajib diisi",
+	"unknown attribute {0}":                                            "atribut {0} tidak dikenal",
+	"attribute {0} must be a number":                                   "atribut {0} harus berupa angka",
+	"attribute {0} must be an integer":                                 "atribut {0} harus berupa bilangan bulat",
+	"attribute {0} must be a boolean":                                  "atribut {0} harus berupa boolean",
+	"attribute {0} must be a string":                                   "atribut {0} harus berupa teks",
+	"attribute {0} must be one of {1}":                                 "atribut {0} harus salah satu dari {1}",
+	"attribute {0} must be at most 255 characters":                     "atribut {0} maksimal 255 karakter",
+	"invalid attribute filter {0}":                                     "filter atribut {0} tidak valid",
+	"invalid attribute name {0}":                                       "nama atribut {0} tidak valid",
+	"attribute filter {0} has no value":                                "filter atribut {0} tidak memiliki nilai",
+	"attribute filter {0} needs a numeric value":                       "filter atribut {0} membutuhkan nilai angka",
+
+	"item not found":                               "barang tidak ditemukan",
+	"item id not found":                            "id barang tidak ditemukan",
+	"item id {0} not found":                        "id barang {0} tidak ditemukan",
+	"item name is already in use":                  "nama barang sudah dipakai",
+	"item is not serialized":                       "barang tidak memakai nomor seri",
+	"insufficient stock":                           "stok tidak mencukupi",
+	"quantity must be at least 1":                  "jumlah minimal 1",
+	"unit not found":                               "unit tidak ditemukan",
+	"unit id not found":                            "id unit tidak ditemukan",
+	"unit id {0} not found":                        "id unit {0} tidak ditemukan",
+	"unit id is required for serialized item":      "id unit wajib diisi untuk barang bernomor seri",
+	"unit is not in stock":                         "unit tidak tersedia di stok",
+	"unit is assigned, check it in first":          "unit sedang dipinjam, kembalikan terlebih dahulu",
+	"unit already has status {0}":                  "unit sudah berstatus {0}",
+	"unit status has changed, try again":           "status unit sudah berubah, coba lagi",
+	"serial number is already in use":              "nomor seri sudah dipakai",
+	"asset tag is already in use":                  "tag aset sudah dipakai",
+	"serial number or asset tag is already in use": "nomor seri atau tag aset sudah dipakai",
+
+	"assignment not found":             "peminjaman tidak ditemukan",
+	"assignment id not found":          "id peminjaman tidak ditemukan",
+	"overdue assignment not found":     "peminjaman yang terlambat tidak ditemukan",
+	"assignment is already checked in": "peminjaman sudah dikembalikan",
+	"assignee not found":               "peminjam tidak ditemukan",
+	"due date must be in the future":   "tanggal jatuh tempo harus berada di masa depan",
+
+	"attachment not found":             "lampiran tidak ditemukan",
+	"attachment id not found":          "id lampiran tidak ditemukan",
+	"attachment file not found":        "berkas lampiran tidak ditemukan",
+	"attachment has no thumbnail":      "lampiran tidak memiliki thumbnail",
+	"file is required":                 "berkas wajib diunggah",
+	"failed to read file":              "gagal membaca berkas",
+	"file is too large":                "berkas terlalu besar",
+	"file exceeds the {0} bytes limit": "berkas melebihi batas {0} byte",
+	"file type {0} is not allowed":     "tipe berkas {0} tidak diizinkan",
+	"unknown file type":                "tipe berkas tidak dikenal",
+
+	"invalid label format":                    "format label tidak valid",
+	"png format supports a single label only": "format png hanya mendukung satu label",
+	"code {0} cannot be printed as a barcode": "kode {0} tidak dapat dicetak sebagai barcode",
+	"code not recognized":                     "kode tidak dikenali",
+	"report not found":                        "laporan tidak ditemukan",
+}
+
+var indonesianValidation = map[string]string{
+	"required_if":      "{0} wajib diisi",
+	"required_unless":  "{0} wajib diisi",
+	"required_without": "{0} wajib diisi",
+}
@@ -0,0 +1,244 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"github.com/jung-kurt/gofpdf"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	itemCodePrefix = "ITEM-"
+	MaxCodeLength  = 80
+
+	labelWidth  = 600
+	labelHeight = 260
+	qrSize      = 220
+	margin      = 20
+
+	sheetColumns      = 2
+	sheetRows         = 7
+	sheetLabelWidth   = 99.1
+	sheetLabelHeight  = 38.1
+	sheetMarginLeft   = 4.65
+	sheetMarginTop    = 15.15
+	sheetColumnGap    = 2.5
+	sheetLabelPadding = 2.0
+)
+
+// CodeError reports a code that does not fit on a Code 128 barcode.
+type CodeError struct {
+	Code string
+}
+
+func (e *CodeError) Error() string {
+	return fmt.Sprintf("label: code %q is not printable ascii of at most %d characters", e.Code, MaxCodeLength)
+}
+
+type Label struct {
+	Code     string
+	Title    string
+	Subtitle string
+}
+
+func ItemCode(itemID int) string {
+	return fmt.Sprintf("%s%06d", itemCodePrefix, itemID)
+}
+
+func ValidCode(code string) bool {
+	if code == "" || len(code) > MaxCodeLength {
+		return false
+	}
+
+	for i := 0; i < len(code); i++ {
+		if code[i] < ' ' || code[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func ParseItemCode(code string) (int, bool) {
+	if !strings.HasPrefix(code, itemCodePrefix) {
+		return 0, false
+	}
+
+	itemID, err := strconv.Atoi(strings.TrimPrefix(code, itemCodePrefix))
+	if err != nil || itemID <= 0 {
+		return 0, false
+	}
+
+	return itemID, true
+}
+
+func WritePNG(w io.Writer, l Label) error {
+	qrCode, err := qrImage(l.Code, qrSize)
+	if err != nil {
+		return err
+	}
+
+	barCode, err := code128Image(l.Code, labelWidth-qrSize-3*margin, 90)
+	if err != nil {
+		return err
+	}
+
+	barWidth := barCode.Bounds().Dx()
+	canvas := image.NewRGBA(image.Rect(0, 0, qrSize+barWidth+3*margin, labelHeight))
+	draw.Draw(canvas, canvas.Bounds(), image.White, image.Point{}, draw.Src)
+
+	qrOrigin := image.Pt(margin, (labelHeight-qrSize)/2)
+	draw.Draw(canvas, qrCode.Bounds().Add(qrOrigin), qrCode, image.Point{}, draw.Src)
+
+	textX := 2*margin + qrSize
+	drawText(canvas, textX, margin+13, truncate(l.Title, barWidth/7))
+	drawText(canvas, textX, margin+33, truncate(l.Subtitle, barWidth/7))
+
+	barOrigin := image.Pt(textX, margin+50)
+	draw.Draw(canvas, barCode.Bounds().Add(barOrigin), barCode, image.Point{}, draw.Src)
+	drawText(canvas, textX, margin+50+90+20, l.Code)
+
+	return png.Encode(w, canvas)
+}
+
+func WritePDF(w io.Writer, labels []Label) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetFont("Helvetica", "", 9)
+
+	perPage := sheetColumns * sheetRows
+	for i, l := range labels {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+
+		position := i % perPage
+		x := sheetMarginLeft + float64(position%sheetColumns)*(sheetLabelWidth+sheetColumnGap)
+		y := sheetMarginTop + float64(position/sheetColumns)*sheetLabelHeight
+		if err := writePDFLabel(pdf, l, i, x, y); err != nil {
+			return err
+		}
+	}
+
+	if len(labels) == 0 {
+		pdf.AddPage()
+	}
+
+	return pdf.Output(w)
+}
+
+func writePDFLabel(pdf *gofpdf.Fpdf, l Label, index int, x, y float64) error {
+	qrCode, err := qrImage(l.Code, qrSize)
+	if err != nil {
+		return err
+	}
+
+	barCode, err := code128Image(l.Code, 400, 90)
+	if err != nil {
+		return err
+	}
+
+	qrName := fmt.Sprintf("qr-%d", index)
+	if err := registerPNG(pdf, qrName, qrCode); err != nil {
+		return err
+	}
+
+	barName := fmt.Sprintf("bar-%d", index)
+	if err := registerPNG(pdf, barName, barCode); err != nil {
+		return err
+	}
+
+	qrMM := sheetLabelHeight - 2*sheetLabelPadding
+	pdf.ImageOptions(qrName, x+sheetLabelPadding, y+sheetLabelPadding, qrMM, qrMM, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	textX := x + qrMM + 2*sheetLabelPadding
+	textWidth := sheetLabelWidth - qrMM - 3*sheetLabelPadding
+	pdf.SetXY(textX, y+sheetLabelPadding)
+	pdf.CellFormat(textWidth, 4, l.Title, "", 2, "L", false, 0, "")
+	pdf.CellFormat(textWidth, 4, l.Subtitle, "", 2, "L", false, 0, "")
+	pdf.ImageOptions(barName, textX, y+sheetLabelPadding+10, textWidth, 14, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	pdf.SetXY(textX, y+sheetLabelPadding+25)
+	pdf.CellFormat(textWidth, 4, l.Code, "", 2, "L", false, 0, "")
+
+	return pdf.Error()
+}
+
+func registerPNG(pdf *gofpdf.Fpdf, name string, img image.Image) error {
+	gray := image.NewGray(img.Bounds())
+	draw.Draw(gray, gray.Bounds(), img, img.Bounds().Min, draw.Src)
+
+	buf := bytes.Buffer{}
+	if err := png.Encode(&buf, gray); err != nil {
+		return err
+	}
+
+	pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: "PNG"}, &buf)
+	return pdf.Error()
+}
+
+func QRCode(content string, size int) ([]byte, error) {
+	qrCode, err := qrImage(content, size)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	if err := png.Encode(&buf, qrCode); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func qrImage(code string, size int) (barcode.Barcode, error) {
+	qrCode, err := qr.Encode(code, qr.M, qr.Auto)
+	if err != nil {
+		return nil, err
+	}
+
+	return barcode.Scale(qrCode, size, size)
+}
+
+func code128Image(code string, width, height int) (barcode.Barcode, error) {
+	if !ValidCode(code) {
+		return nil, &CodeError{Code: code}
+	}
+
+	barCode, err := code128.Encode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return barcode.Scale(barCode, max(width, barCode.Bounds().Dx()), height)
+}
+
+func drawText(canvas draw.Image, x, y int, text string) {
+	drawer := font.Drawer{
+		Dst:  canvas,
+		Src:  image.NewUniform(color.Black),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+}
+
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+
+	return string(runes[:limit-3]) + "..."
+}
@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"log/slog"
+)
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	usernameKey
+)
+
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+func WithUsername(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, usernameKey, username)
+}
+
+func Username(ctx context.Context) string {
+	username, _ := ctx.Value(usernameKey).(string)
+	return username
+}
+
+func FromContext(ctx context.Context) *slog.Logger {
+	logger := slog.Default()
+	if requestID := RequestID(ctx); requestID != "" {
+		logger = logger.With("request_id", requestID)
+	}
+	if username := Username(ctx); username != "" {
+		logger = logger.With("username", username)
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		logger = logger.With("trace_id", spanContext.TraceID().String())
+	}
+
+	return logger
+}
@@ -0,0 +1,13 @@
+package mail
+
+import "context"
+
+type discardMailer struct{}
+
+func NewDiscardMailer() Mailer {
+	return discardMailer{}
+}
+
+func (discardMailer) Send(ctx context.Context, message Message) error {
+	return nil
+}
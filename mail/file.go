@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type fileMailer struct {
+	root string
+	from string
+}
+
+func NewFileMailer(root string, from string) (Mailer, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(absRoot, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &fileMailer{absRoot, from}, nil
+}
+
+func (f *fileMailer) Send(ctx context.Context, message Message) error {
+	now := time.Now()
+	content, err := encode(f.from, message, now)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(f.root, now.UTC().Format("20060102T150405")+"-*.eml")
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,68 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"inventory-management-system/config"
+	"mime"
+	"strings"
+	"time"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+func New(mailConfig config.Mail) (Mailer, error) {
+	switch mailConfig.Driver {
+	case "none":
+		return NewDiscardMailer(), nil
+	case "log":
+		return NewLogMailer(mailConfig.From), nil
+	case "file":
+		return NewFileMailer(mailConfig.FilePath, mailConfig.From)
+	case "smtp":
+		return NewSMTPMailer(mailConfig.SMTPHost, mailConfig.SMTPPort, mailConfig.SMTPUsername, mailConfig.SMTPPassword, mailConfig.From), nil
+	default:
+		return nil, fmt.Errorf("mail: unknown driver %q", mailConfig.Driver)
+	}
+}
+
+func encode(from string, message Message, now time.Time) ([]byte, error) {
+	for _, value := range []string{from, message.To, message.Subject} {
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("mail: header contains a line break")
+		}
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	domain := "localhost"
+	if _, host, ok := strings.Cut(from, "@"); ok {
+		domain = strings.TrimSuffix(host, ">")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", message.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", hex.EncodeToString(id), domain)
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return buf.Bytes(), nil
+}
@@ -1,58 +1,212 @@
 package main
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"inventory-management-system/app"
+	"inventory-management-system/config"
 	"inventory-management-system/controller"
 	"inventory-management-system/helper"
+	"inventory-management-system/i18n"
+	"inventory-management-system/logging"
+	"inventory-management-system/mail"
+	"inventory-management-system/metrics"
+	"inventory-management-system/middleware"
 	"inventory-management-system/model/domain"
+	"inventory-management-system/oidc"
+	"inventory-management-system/password"
+	"inventory-management-system/ratelimit"
 	"inventory-management-system/repository"
 	"inventory-management-system/service"
+	"inventory-management-system/signing"
+	"inventory-management-system/storage"
+	"inventory-management-system/tracing"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
-	dbCredential := domain.Credential{
-		Host:         "localhost",
-		Username:     "postgres",
-		Password:     "postgres",
-		DatabaseName: "inventory_test",
-		Port:         5432,
-		Schema:       "public",
+	if err := run(); err != nil {
+		slog.Error("server stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg := config.Load()
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Log.Level)); err != nil {
+		return err
+	}
+	logger := logging.New(os.Stdout, level)
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			slog.Error("tracing shutdown failed", "error", err)
+		}
+	}()
+
+	database, err := app.NewDatabase(cfg.Database)
+	if err != nil {
+		return err
 	}
 
-	postgres := *app.NewDB()
-	connection, err := postgres.Connect(&dbCredential)
+	connection, err := app.ConnectWithRetry(ctx, database, cfg.Database.ConnectAttempts, cfg.Database.ConnectBackoff)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	defer app.Close(connection)
 
-	err = connection.AutoMigrate(domain.Users{}, domain.Sessions{}, domain.Items{}, domain.Categories{}, domain.Activities{})
+	err = connection.Use(tracing.NewGormPlugin())
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	validate := *validator.New()
-	handleRepository := repository.NewHandlerRepository(connection)
-	userService := service.NewUserService(handleRepository)
-	reportService := service.NewReportService(handleRepository)
-	categoryService := service.NewCategoryService(handleRepository)
-	itemService := service.NewItemService(handleRepository)
-	userController := controller.NewUserController(userService, &validate)
+	err = app.ClearEmptyEmails(connection)
+	if err != nil {
+		return err
+	}
+
+	err = connection.AutoMigrate(
+		domain.Users{}, domain.Sessions{}, domain.Items{}, domain.Categories{}, domain.Activities{},
+		domain.Assignments{}, domain.ItemUnits{}, domain.Attachments{},
+		domain.CategoryAttributes{}, domain.ItemAttributeValues{}, domain.LoginAttempts{}, domain.APIKeys{}, domain.PasswordResets{},
+	)
+	if err != nil {
+		return err
+	}
+
+	fileStorage, err := storage.New(cfg.Storage)
+	if err != nil {
+		return err
+	}
+
+	keys, err := signing.Load(cfg.Signing)
+	if err != nil {
+		return err
+	}
+
+	mailer, err := mail.New(cfg.Mail)
+	if err != nil {
+		return err
+	}
+	if cfg.Mail.Driver == "none" {
+		slog.Warn("MAIL_DRIVER is not set, mail such as password reset links is discarded")
+	}
+
+	sqlDB, err := connection.DB()
+	if err != nil {
+		return err
+	}
+
+	validate := helper.NewValidator()
+	translator, err := i18n.New(validate)
+	if err != nil {
+		return err
+	}
+
+	transactor := repository.NewTransactor(connection)
+	userRepository := repository.NewUserRepository(connection)
+	sessionRepository := repository.NewSessionRepository(connection)
+	itemRepository := repository.NewItemRepository(connection)
+	categoryRepository := repository.NewCategoryRepository(connection)
+	activityRepository := repository.NewActivityRepository(connection)
+	assignmentRepository := repository.NewAssignmentRepository(connection)
+	itemUnitRepository := repository.NewItemUnitRepository(connection)
+	attachmentRepository := repository.NewAttachmentRepository(connection)
+	healthRepository := repository.NewHealthRepository(connection)
+	loginAttemptRepository := repository.NewLoginAttemptRepository(connection)
+	apiKeyRepository := repository.NewAPIKeyRepository(connection)
+	passwordResetRepository := repository.NewPasswordResetRepository(connection)
+	passwordPolicy := password.New(cfg.Password)
+	userService := service.NewUserService(transactor, userRepository, sessionRepository, loginAttemptRepository, activityRepository,
+		ratelimit.NewFixedWindow(cfg.Login.UsernameLimit, cfg.Login.RateWindow), cfg.Login.MaxFailures, cfg.Login.LockoutDuration,
+		cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL, keys, cfg.MFA, passwordPolicy)
+	reportService := service.NewReportService(activityRepository, itemRepository)
+	categoryService := service.NewCategoryService(categoryRepository)
+	itemService := service.NewItemService(transactor, itemRepository, categoryRepository, activityRepository)
+	assignmentService := service.NewAssignmentService(transactor, assignmentRepository, itemRepository, itemUnitRepository, userRepository, activityRepository)
+	itemUnitService := service.NewItemUnitService(transactor, itemUnitRepository, itemRepository, activityRepository)
+	labelService := service.NewLabelService(itemRepository, itemUnitRepository)
+	attachmentService := service.NewAttachmentService(transactor, attachmentRepository, itemRepository, activityRepository, fileStorage, cfg.Storage.MaxUploadSize)
+	healthService := service.NewHealthService(healthRepository)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepository)
+	twoFactorService := service.NewTwoFactorService(userRepository, cfg.MFA)
+	passwordResetService := service.NewPasswordResetService(transactor, userRepository, sessionRepository, passwordResetRepository, mailer, passwordPolicy, cfg.Password, cfg.Mail)
+	recorder := metrics.New()
+	err = recorder.Register(
+		collectors.NewDBStatsCollector(sqlDB, cfg.Database.Driver),
+		metrics.NewInventoryCollector(itemRepository, cfg.Metrics.ReorderPoint),
+	)
+	if err != nil {
+		return err
+	}
+
+	userController := controller.NewUserController(userService, validate, recorder, cfg.Cookie)
 	reportController := controller.NewReportController(reportService)
-	categoryController := controller.NewCategoryController(categoryService, &validate)
-	itemController := controller.NewItemController(itemService, &validate)
+	categoryController := controller.NewCategoryController(categoryService, validate)
+	itemController := controller.NewItemController(itemService, validate)
+	assignmentController := controller.NewAssignmentController(assignmentService, validate)
+	itemUnitController := controller.NewItemUnitController(itemUnitService, validate)
+	labelController := controller.NewLabelController(labelService, validate)
+	attachmentController := controller.NewAttachmentController(attachmentService, validate)
+	healthController := controller.NewHealthController(healthService)
+	apiKeyController := controller.NewAPIKeyController(apiKeyService, validate)
+	twoFactorController := controller.NewTwoFactorController(twoFactorService, validate)
+	profileController := controller.NewProfileController(userService, validate, cfg.Cookie)
+	passwordResetController := controller.NewPasswordResetController(passwordResetService, validate)
 
-	postgres.Reset(connection, "users")
-	helper.RegisterAdmin(handleRepository)
+	if cfg.Admin.Seed {
+		err = helper.RegisterAdmin(ctx, userRepository, passwordPolicy, cfg.Admin.Password)
+		if err != nil {
+			return err
+		}
+	}
 
 	apiServer := gin.New()
-	app.UserRouter(apiServer, userController)
-	app.CategoryRouter(apiServer, categoryController)
-	app.ItemRouter(apiServer, itemController)
-	app.ReportRouter(apiServer, reportController)
-	err = apiServer.Run(":8080")
-	if err != nil {
-		panic(err)
+	apiServer.Use(middleware.Tracing(), middleware.RequestID(), middleware.Locale(translator), middleware.Logger(logger), middleware.Metrics(recorder), middleware.Recovery(logger))
+	app.MetricsRouter(apiServer, recorder)
+	app.HealthRouter(apiServer, healthController, cfg.Timeouts)
+	app.JWKSRouter(apiServer, keys)
+	authenticate := middleware.Auth(keys, apiKeyService, userService)
+	loginLimiter := ratelimit.NewFixedWindow(cfg.Login.IPLimit, cfg.Login.RateWindow)
+	app.UserRouter(apiServer, userController, authenticate, loginLimiter, cfg.Timeouts)
+	app.PasswordResetRouter(apiServer, passwordResetController, loginLimiter, cfg.Timeouts)
+	if cfg.OIDC.Issuer != "" {
+		provider, err := oidc.New(cfg.OIDC, &http.Client{Transport: tracing.NewTransport(http.DefaultTransport), Timeout: cfg.Timeouts.Default})
+		if err != nil {
+			return err
+		}
+
+		oidcService := service.NewOIDCService(provider, userService, cfg.OIDC.GroupsClaim, cfg.OIDC.GroupRoles, cfg.OIDC.DefaultRole)
+		app.OIDCRouter(apiServer, controller.NewOIDCController(oidcService, recorder, cfg.Cookie, cfg.OIDC.PostLoginRedirect), loginLimiter, cfg.Timeouts)
 	}
+	app.ProfileRouter(apiServer, profileController, authenticate, cfg.Timeouts)
+	app.TwoFactorRouter(apiServer, twoFactorController, authenticate, cfg.Timeouts)
+	app.CategoryRouter(apiServer, categoryController, authenticate, cfg.Timeouts)
+	app.ItemRouter(apiServer, itemController, authenticate, cfg.Timeouts)
+	app.ReportRouter(apiServer, reportController, authenticate, cfg.Timeouts)
+	app.AssignmentRouter(apiServer, assignmentController, authenticate, cfg.Timeouts)
+	app.ItemUnitRouter(apiServer, itemUnitController, authenticate, cfg.Timeouts)
+	app.LabelRouter(apiServer, labelController, authenticate, cfg.Timeouts)
+	app.AttachmentRouter(apiServer, attachmentController, authenticate, cfg.Storage.MaxUploadSize, cfg.Timeouts)
+	app.APIKeyRouter(apiServer, apiKeyController, authenticate, cfg.Timeouts)
+
+	server := app.NewServer(cfg.Server.Address, apiServer)
+	slog.Info("server listening", "address", cfg.Server.Address)
+	return app.Serve(ctx, server, cfg.Server.ShutdownTimeout, passwordResetService.Drain)
 }
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"inventory-management-system/repository"
+	"time"
+)
+
+const collectTimeout = 5 * time.Second
+
+var (
+	itemsDesc = prometheus.NewDesc(
+		"inventory_items",
+		"Number of items in the inventory.",
+		nil, nil,
+	)
+	stockQuantityDesc = prometheus.NewDesc(
+		"inventory_stock_quantity",
+		"Total quantity in stock across all items.",
+		nil, nil,
+	)
+	stockValueDesc = prometheus.NewDesc(
+		"inventory_stock_value",
+		"Total value of the stock, price times quantity across all items.",
+		nil, nil,
+	)
+	belowReorderPointDesc = prometheus.NewDesc(
+		"inventory_items_below_reorder_point",
+		"Number of items whose quantity is at or below the reorder point.",
+		nil, nil,
+	)
+)
+
+type inventoryCollector struct {
+	repository.ItemRepository
+	reorderPoint int
+}
+
+func NewInventoryCollector(itemRepository repository.ItemRepository, reorderPoint int) prometheus.Collector {
+	return &inventoryCollector{itemRepository, reorderPoint}
+}
+
+func (i *inventoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- itemsDesc
+	ch <- stockQuantityDesc
+	ch <- stockValueDesc
+	ch <- belowReorderPointDesc
+}
+
+func (i *inventoryCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	items, err := i.ItemRepository.FindAll(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(itemsDesc, err)
+		return
+	}
+
+	var quantity, belowReorderPoint int
+	var value float64
+	for _, item := range items {
+		quantity += item.Quantity
+		value += item.Price * float64(item.Quantity)
+		if item.Quantity <= i.reorderPoint {
+			belowReorderPoint++
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(itemsDesc, prometheus.GaugeValue, float64(len(items)))
+	ch <- prometheus.MustNewConstMetric(stockQuantityDesc, prometheus.GaugeValue, float64(quantity))
+	ch <- prometheus.MustNewConstMetric(stockValueDesc, prometheus.GaugeValue, value)
+	ch <- prometheus.MustNewConstMetric(belowReorderPointDesc, prometheus.GaugeValue, float64(belowReorderPoint))
+}
@@ -1,50 +1,378 @@
 package middleware
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
 	"github.com/golang-jwt/jwt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"inventory-management-system/helper"
+	"inventory-management-system/i18n"
+	"inventory-management-system/logging"
+	"inventory-management-system/metrics"
 	"inventory-management-system/model/domain"
 	"inventory-management-system/model/web"
+	"inventory-management-system/ratelimit"
+	"inventory-management-system/signing"
+	"inventory-management-system/tracing"
+	"log/slog"
 	"net/http"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
-func Auth() gin.HandlerFunc {
+const RequestIDHeader = "X-Request-ID"
+
+const (
+	CSRFCookie = "csrf_token"
+	CSRFHeader = "X-CSRF-Token"
+)
+
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (domain.APIKeys, web.ErrorResponse)
+}
+
+type SessionVerifier interface {
+	VerifySession(ctx context.Context, accessToken string) (domain.Users, web.ErrorResponse)
+}
+
+func Auth(keys signing.KeySet, apiKeys APIKeyAuthenticator, sessions SessionVerifier) gin.HandlerFunc {
 	return gin.HandlerFunc(func(ctx *gin.Context) {
-		sessionToken, err := ctx.Cookie("session_token")
-		if err != nil || sessionToken == "" {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, web.NewUnauthorizedError("session token is empty"))
+		sessionToken := bearerToken(ctx)
+		apiKey := ctx.GetHeader("X-API-Key")
+		if apiKey == "" && strings.HasPrefix(sessionToken, domain.APIKeyPrefix) {
+			apiKey = sessionToken
+		}
+
+		if apiKey != "" {
+			key, errResponse := apiKeys.Authenticate(ctx.Request.Context(), apiKey)
+			if errResponse != nil {
+				helper.AbortWithError(ctx, errResponse)
+				return
+			}
+
+			username := "apikey:" + key.Name
+			ctx.Set("username", username)
+			ctx.Set("role", domain.RoleService)
+			ctx.Set("scopes", key.Scopes)
+			ctx.Set("auth_method", "api_key")
+			ctx.Request = ctx.Request.WithContext(logging.WithUsername(ctx.Request.Context(), username))
+			ctx.Next()
+			return
+		}
+
+		authMethod := "bearer"
+		if sessionToken == "" {
+			authMethod = "cookie"
+			sessionToken, _ = ctx.Cookie("session_token")
+		}
+
+		if sessionToken == "" {
+			helper.AbortWithError(ctx, web.NewUnauthorizedError(web.CodeTokenMissing, "session token is empty"))
 			return
 		}
 
 		tokenClaims := &domain.JwtCustomClaims{}
-		token, err := jwt.ParseWithClaims(sessionToken, tokenClaims, func(token *jwt.Token) (any, error) {
-			return domain.JwtKey, err
-		})
+		token, err := keys.Parse(sessionToken, tokenClaims)
+		var validationErr *jwt.ValidationError
+		if errors.As(err, &validationErr) && validationErr.Errors&jwt.ValidationErrorExpired != 0 {
+			helper.AbortWithError(ctx, web.NewUnauthorizedError(web.CodeTokenExpired, "token is expired"))
+			return
+		}
 
-		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, web.NewBadRequestError("parse token failed"))
+		if err != nil || !token.Valid || tokenClaims.Audience != "" {
+			helper.AbortWithError(ctx, web.NewUnauthorizedError(web.CodeTokenInvalid, "token is invalid"))
 			return
 		}
 
-		if !token.Valid {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, web.NewUnauthorizedError("token is invalid"))
+		user, errResponse := sessions.VerifySession(ctx.Request.Context(), sessionToken)
+		if errResponse != nil {
+			helper.AbortWithError(ctx, errResponse)
 			return
 		}
-		
-		ctx.Set("username", tokenClaims.Username)
-		ctx.Set("role", tokenClaims.Role)
+
+		ctx.Set("username", user.Username)
+		ctx.Set("role", user.Role)
+		ctx.Set("auth_method", authMethod)
+		ctx.Set("session_token", sessionToken)
+		ctx.Request = ctx.Request.WithContext(logging.WithUsername(ctx.Request.Context(), user.Username))
 		ctx.Next()
 	})
 }
 
+func CSRF() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		switch ctx.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			ctx.Next()
+			return
+		}
+
+		if !cookieAuthenticated(ctx) {
+			ctx.Next()
+			return
+		}
+
+		cookie, _ := ctx.Cookie(CSRFCookie)
+		header := ctx.GetHeader(CSRFHeader)
+		if cookie == "" || subtle.ConstantTimeCompare([]byte(cookie), []byte(header)) != 1 {
+			helper.AbortWithError(ctx, web.NewForbiddenError(web.CodeCSRFTokenInvalid, "csrf token is invalid"))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func cookieAuthenticated(ctx *gin.Context) bool {
+	if authMethod, exists := ctx.Get("auth_method"); exists {
+		return authMethod == "cookie"
+	}
+
+	_, err := ctx.Cookie("refresh_token")
+	return err == nil
+}
+
+func Scope(resource string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		action := "write"
+		switch ctx.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			action = "read"
+		}
+
+		requireScope(ctx, resource+":"+action)
+	}
+}
+
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requireScope(ctx, scope)
+	}
+}
+
+func requireScope(ctx *gin.Context, scope string) {
+	if ctx.GetString("role") == domain.RoleService {
+		scopes := ctx.GetStringSlice("scopes")
+		if !slices.Contains(scopes, scope) && !slices.Contains(scopes, "*") {
+			helper.AbortWithError(ctx, web.NewForbiddenError(web.CodeAPIKeyScopeMissing, "api key is missing scope {0}", scope))
+			return
+		}
+	}
+
+	ctx.Next()
+}
+
+func bearerToken(ctx *gin.Context) string {
+	scheme, token, ok := strings.Cut(ctx.GetHeader("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
+
 func AdminOnly() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		role, exists := ctx.Get("role")
 		if !exists || role != "admin" {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, web.NewUnauthorizedError("user is not admin"))
+			helper.AbortWithError(ctx, web.NewUnauthorizedError(web.CodeAdminRequired, "user is not admin"))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func BodyLimit(limit int64) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limit)
+		ctx.Next()
+	}
+}
+
+func RateLimit(limiter ratelimit.Limiter) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		allowed, retryAfter := limiter.Allow(ctx.ClientIP())
+		if !allowed {
+			ctx.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			helper.AbortWithError(ctx, web.NewTooManyRequestsError(web.CodeRateLimited, "too many requests, try again later"))
 			return
 		}
 
 		ctx.Next()
 	}
 }
+
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if timeout <= 0 {
+			ctx.Next()
+			return
+		}
+
+		requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), timeout)
+		defer cancel()
+
+		ctx.Request = ctx.Request.WithContext(requestCtx)
+		ctx.Next()
+
+		if errors.Is(requestCtx.Err(), context.DeadlineExceeded) && !ctx.Writer.Written() {
+			helper.AbortWithError(ctx, web.NewGatewayTimeoutError())
+		}
+	}
+}
+
+func Metrics(recorder metrics.Metrics) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		recorder.ObserveRequest(ctx.Request.Method, route, ctx.Writer.Status(), time.Since(start))
+	}
+}
+
+func Tracing() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		parent := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+		spanCtx, span := tracing.Tracer().Start(parent, ctx.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(ctx.Request.URL.Path),
+				semconv.ClientAddress(ctx.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		ctx.Request = ctx.Request.WithContext(spanCtx)
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if username := ctx.GetString("username"); username != "" {
+			span.SetAttributes(semconv.EnduserID(username))
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = newRequestID()
+		}
+
+		ctx.Set("request_id", requestID)
+		ctx.Header(RequestIDHeader, requestID)
+		ctx.Request = ctx.Request.WithContext(logging.WithRequestID(ctx.Request.Context(), requestID))
+		ctx.Next()
+	}
+}
+
+func Locale(universal *ut.UniversalTranslator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set("translator", i18n.Match(universal, ctx.GetHeader("Accept-Language")))
+		ctx.Header("Vary", "Accept-Language")
+		ctx.Next()
+	}
+}
+
+func Logger(logger *slog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		} else if status >= http.StatusBadRequest {
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("request_id", ctx.GetString("request_id")),
+			slog.String("method", ctx.Request.Method),
+			slog.String("path", ctx.Request.URL.Path),
+			slog.String("route", ctx.FullPath()),
+			slog.Int("status", status),
+			slog.Int("bytes", ctx.Writer.Size()),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", ctx.ClientIP()),
+		}
+		if username := ctx.GetString("username"); username != "" {
+			attrs = append(attrs, slog.String("username", username))
+		}
+		if spanContext := trace.SpanContextFromContext(ctx.Request.Context()); spanContext.HasTraceID() {
+			attrs = append(attrs, slog.String("trace_id", spanContext.TraceID().String()))
+		}
+		if len(ctx.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", ctx.Errors.String()))
+		}
+
+		logger.LogAttrs(ctx.Request.Context(), level, "request", attrs...)
+	}
+}
+
+func Recovery(logger *slog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			logger.LogAttrs(ctx.Request.Context(), slog.LevelError, "panic recovered",
+				slog.String("request_id", ctx.GetString("request_id")),
+				slog.String("username", ctx.GetString("username")),
+				slog.String("panic", fmt.Sprint(recovered)),
+				slog.String("stack", string(debug.Stack())),
+			)
+
+			if ctx.Writer.Written() {
+				ctx.Abort()
+				return
+			}
+
+			helper.AbortWithError(ctx, web.NewInternalServerErrorError())
+		}()
+
+		ctx.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+const (
+	APIKeyPrefix = "ims_"
+	RoleService  = "service"
+)
+
+var APIKeyScopes = []string{
+	"categories:read", "categories:write",
+	"items:read", "items:write",
+	"units:read", "units:write",
+	"assignments:read", "assignments:write",
+	"attachments:read", "attachments:write",
+	"labels:read",
+	"reports:read",
+}
+
+type APIKeys struct {
+	ID         int        `gorm:"primaryKey;column:id;autoIncrement" json:"id"`
+	Name       string     `gorm:"column:name;not null" json:"name"`
+	Prefix     string     `gorm:"column:prefix;not null" json:"prefix"`
+	KeyHash    string     `gorm:"column:key_hash;uniqueIndex;not null" json:"-"`
+	Scopes     []string   `gorm:"column:scopes;serializer:json" json:"scopes"`
+	CreatedBy  string     `gorm:"column:created_by" json:"created_by"`
+	CreatedAt  time.Time  `gorm:"column:created_at" json:"created_at"`
+	ExpiresAt  *time.Time `gorm:"column:expires_at" json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at" json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at" json:"revoked_at,omitempty"`
+}
+
+type APIKeyCreated struct {
+	APIKeys
+	Key string `json:"key"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+type Assignments struct {
+	ID           int        `gorm:"primaryKey;column:id;AUTO_INCREMENT" json:"id"`
+	ItemID       int        `gorm:"column:item_id;not null" json:"item_id"`
+	UnitID       *int       `gorm:"column:unit_id" json:"unit_id"`
+	Assignee     string     `gorm:"column:assignee;not null" json:"assignee"`
+	Quantity     int        `gorm:"column:quantity;not null" json:"quantity"`
+	DueDate      time.Time  `gorm:"column:due_date;not null" json:"due_date"`
+	Note         string     `gorm:"column:note" json:"note"`
+	CheckedOutBy string     `gorm:"column:checked_out_by;not null" json:"checked_out_by"`
+	CheckedOutAt time.Time  `gorm:"column:checked_out_at;not null" json:"checked_out_at"`
+	CheckedInBy  string     `gorm:"column:checked_in_by" json:"checked_in_by"`
+	CheckedInAt  *time.Time `gorm:"column:checked_in_at" json:"checked_in_at"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+type Attachments struct {
+	ID           int       `gorm:"primaryKey;column:id;AUTO_INCREMENT" json:"id"`
+	ItemID       int       `gorm:"column:item_id;not null;index" json:"item_id"`
+	Kind         string    `gorm:"column:kind;not null" json:"kind"`
+	FileName     string    `gorm:"column:file_name;not null" json:"file_name"`
+	ContentType  string    `gorm:"column:content_type;not null" json:"content_type"`
+	Size         int64     `gorm:"column:size;not null" json:"size"`
+	StorageKey   string    `gorm:"column:storage_key;not null" json:"-"`
+	ThumbnailKey string    `gorm:"column:thumbnail_key" json:"-"`
+	HasThumbnail bool      `gorm:"column:has_thumbnail;not null;default:false" json:"has_thumbnail"`
+	UploadedBy   string    `gorm:"column:uploaded_by;not null" json:"uploaded_by"`
+	CreatedAt    time.Time `gorm:"column:created_at" json:"created_at"`
+}
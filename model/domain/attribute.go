@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeInteger = "integer"
+	AttributeTypeNumber  = "number"
+	AttributeTypeBoolean = "boolean"
+	AttributeTypeEnum    = "enum"
+)
+
+type CategoryAttributes struct {
+	ID         int       `gorm:"primaryKey;column:id;AUTO_INCREMENT" json:"id"`
+	CategoryID int       `gorm:"column:category_id;not null;uniqueIndex:idx_category_attribute_name" json:"category_id"`
+	Name       string    `gorm:"column:name;not null;uniqueIndex:idx_category_attribute_name" json:"name"`
+	Label      string    `gorm:"column:label" json:"label"`
+	Type       string    `gorm:"column:type;not null" json:"type"`
+	Unit       string    `gorm:"column:unit" json:"unit"`
+	Options    []string  `gorm:"column:options;serializer:json" json:"options,omitempty"`
+	Required   bool      `gorm:"column:required;not null;default:false" json:"required"`
+	CreatedAt  time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+type ItemAttributeValues struct {
+	ID          int      `gorm:"primaryKey;column:id;AUTO_INCREMENT"`
+	ItemID      int      `gorm:"column:item_id;not null;uniqueIndex:idx_item_attribute_name"`
+	AttributeID int      `gorm:"column:attribute_id;not null;index"`
+	Name        string   `gorm:"column:name;not null;uniqueIndex:idx_item_attribute_name"`
+	Type        string   `gorm:"column:type;not null"`
+	Value       string   `gorm:"column:value;not null"`
+	NumberValue *float64 `gorm:"column:number_value"`
+}
+
+type AttributeFilter struct {
+	Name     string
+	Operator string
+	Value    string
+}
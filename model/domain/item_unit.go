@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+const (
+	UnitStatusInStock  = "in_stock"
+	UnitStatusAssigned = "assigned"
+	UnitStatusInRepair = "in_repair"
+	UnitStatusRetired  = "retired"
+)
+
+type ItemUnits struct {
+	ID           int       `gorm:"primaryKey;column:id;AUTO_INCREMENT" json:"id"`
+	ItemID       int       `gorm:"column:item_id;not null;index" json:"item_id"`
+	SerialNumber string    `gorm:"column:serial_number;not null;unique" json:"serial_number"`
+	AssetTag     string    `gorm:"column:asset_tag;not null;unique" json:"asset_tag"`
+	Status       string    `gorm:"column:status;not null" json:"status"`
+	Note         string    `gorm:"column:note" json:"note"`
+	CreatedAt    time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+type ScanResult struct {
+	Code string     `json:"code"`
+	Type string     `json:"type"`
+	Item Items      `json:"item"`
+	Unit *ItemUnits `json:"unit,omitempty"`
+}
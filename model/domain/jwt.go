@@ -1,11 +1,22 @@
 package domain
 
-import "github.com/golang-jwt/jwt"
-
-var JwtKey = []byte("secret-key")
+import (
+	"github.com/golang-jwt/jwt"
+	"time"
+)
 
 type JwtCustomClaims struct {
 	Username string `json:"username"`
 	Role     string `json:"role"`
 	jwt.StandardClaims
 }
+
+type TokenPair struct {
+	AccessToken      string    `json:"access_token,omitempty"`
+	TokenType        string    `json:"token_type"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token,omitempty"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	CSRFToken        string    `json:"csrf_token,omitempty"`
+	RecoveryCodes    []string  `json:"recovery_codes,omitempty"`
+}
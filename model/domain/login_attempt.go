@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+const (
+	LoginSuccess         = "success"
+	LoginUnknownUser     = "unknown_user"
+	LoginInvalidPassword = "invalid_password"
+	LoginLocked          = "locked"
+	LoginRateLimited     = "rate_limited"
+	LoginSSORejected     = "sso_rejected"
+	LoginMFARequired     = "mfa_required"
+	LoginInvalidMFA      = "invalid_mfa"
+	LoginDeactivated     = "deactivated"
+)
+
+type LoginAttempts struct {
+	ID        int       `gorm:"primaryKey;column:id;autoIncrement" json:"id"`
+	Username  string    `gorm:"column:username;index" json:"username"`
+	IPAddress string    `gorm:"column:ip_address" json:"ip_address"`
+	Success   bool      `gorm:"column:success" json:"success"`
+	Reason    string    `gorm:"column:reason" json:"reason"`
+	Timestamp time.Time `gorm:"column:timestamp" json:"timestamp"`
+	RequestID string    `gorm:"column:request_id" json:"request_id,omitempty"`
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+type PasswordResets struct {
+	ID        int        `gorm:"primaryKey;column:id;autoIncrement" json:"id"`
+	Username  string     `gorm:"column:username;index;not null" json:"username"`
+	TokenHash string     `gorm:"column:token_hash;uniqueIndex;not null" json:"-"`
+	CreatedAt time.Time  `gorm:"column:created_at" json:"created_at"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null" json:"expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at" json:"used_at,omitempty"`
+}
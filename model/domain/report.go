@@ -2,13 +2,30 @@ package domain
 
 import "time"
 
+const (
+	ActionPost     = "POST"
+	ActionUpdate   = "UPDATE"
+	ActionDelete   = "DELETE"
+	ActionCheckOut = "CHECK_OUT"
+	ActionCheckIn  = "CHECK_IN"
+
+	ActionUnitAdd    = "UNIT_ADD"
+	ActionUnitStatus = "UNIT_STATUS"
+
+	ActionAttach = "ATTACH"
+	ActionDetach = "DETACH"
+)
+
 type Activities struct {
 	ID             int       `gorm:"primary_key;column:id;auto_increment" json:"id"`
 	ItemID         int       `gorm:"column:item_id" json:"item_id"`
+	UnitID         *int      `gorm:"column:unit_id" json:"unit_id"`
 	Action         string    `gorm:"column:action" json:"action"`
 	QuantityChange int       `gorm:"column:quantity_change" json:"quantity_change"`
 	Timestamp      time.Time `gorm:"column:timestamp" json:"timestamp"`
 	PerformedBy    string    `gorm:"column:performed_by" json:"performed_by"`
+	Note           string    `gorm:"column:note" json:"note"`
+	RequestID      string    `gorm:"column:request_id" json:"request_id,omitempty"`
 }
 
 type ReportStock struct {
@@ -10,4 +10,10 @@ type Sessions struct {
 	Username  string    `gorm:"column:username;not null" json:"username"`
 	Token     string    `gorm:"column:token;unique;not null" json:"token"`
 	ExpiresAt time.Time `gorm:"column:expires_at;not null" json:"expires_at"`
+
+	FamilyID         string     `gorm:"column:family_id;index" json:"family_id"`
+	RefreshTokenHash string     `gorm:"column:refresh_token_hash;index" json:"-"`
+	RefreshExpiresAt time.Time  `gorm:"column:refresh_expires_at" json:"refresh_expires_at"`
+	RotatedAt        *time.Time `gorm:"column:rotated_at" json:"rotated_at,omitempty"`
+	RevokedAt        *time.Time `gorm:"column:revoked_at" json:"revoked_at,omitempty"`
 }
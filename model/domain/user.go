@@ -1,6 +1,9 @@
 package domain
 
-import "gorm.io/gorm"
+import (
+	"gorm.io/gorm"
+	"time"
+)
 
 type Users struct {
 	gorm.Model
@@ -8,4 +11,20 @@ type Users struct {
 	Username string `gorm:"column:username;unique" json:"username"`
 	Password string `gorm:"column:password"`
 	Role     string `gorm:"column:role" json:"role"`
+	Active   bool   `gorm:"column:active;not null;default:true" json:"active"`
+
+	Email       *string `gorm:"column:email;uniqueIndex" json:"email,omitempty"`
+	OIDCSubject *string `gorm:"column:oidc_subject;uniqueIndex" json:"oidc_subject,omitempty"`
+
+	TwoFactor `gorm:"embedded"`
+
+	FailedLogins int        `gorm:"column:failed_logins;not null;default:0" json:"failed_logins"`
+	LockedUntil  *time.Time `gorm:"column:locked_until" json:"locked_until,omitempty"`
+}
+
+type TwoFactor struct {
+	TOTPSecret    string   `gorm:"column:totp_secret" json:"-"`
+	TOTPEnabled   bool     `gorm:"column:totp_enabled;not null;default:false" json:"totp_enabled"`
+	TOTPLastStep  int64    `gorm:"column:totp_last_step;not null;default:0" json:"-"`
+	RecoveryCodes []string `gorm:"column:recovery_codes;serializer:json" json:"-"`
 }
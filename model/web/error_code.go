@@ -0,0 +1,103 @@
+package web
+
+const (
+	CodeInternalError       = "INTERNAL_ERROR"
+	CodeDeadlineExceeded    = "DEADLINE_EXCEEDED"
+	CodeDatabaseUnavailable = "DATABASE_UNAVAILABLE"
+	CodeRateLimited         = "RATE_LIMITED"
+
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeInvalidBody      = "INVALID_BODY"
+	CodeInvalidID        = "INVALID_ID"
+	CodeInvalidQuery     = "INVALID_QUERY"
+
+	CodeTokenMissing             = "TOKEN_MISSING"
+	CodeTokenExpired             = "TOKEN_EXPIRED"
+	CodeTokenInvalid             = "TOKEN_INVALID"
+	CodeTokenRevoked             = "TOKEN_REVOKED"
+	CodeCSRFTokenInvalid         = "CSRF_TOKEN_INVALID"
+	CodeAdminRequired            = "ADMIN_REQUIRED"
+	CodeRefreshTokenMissing      = "REFRESH_TOKEN_MISSING"
+	CodeRefreshTokenInvalid      = "REFRESH_TOKEN_INVALID"
+	CodeRefreshTokenExpired      = "REFRESH_TOKEN_EXPIRED"
+	CodeRefreshTokenReused       = "REFRESH_TOKEN_REUSED"
+	CodeInvalidCredentials       = "INVALID_CREDENTIALS"
+	CodeTooManyLoginAttempts     = "TOO_MANY_LOGIN_ATTEMPTS"
+	CodeAccountDeactivated       = "ACCOUNT_DEACTIVATED"
+	CodeAPIKeyInvalid            = "API_KEY_INVALID"
+	CodeAPIKeyExpired            = "API_KEY_EXPIRED"
+	CodeAPIKeyRevoked            = "API_KEY_REVOKED"
+	CodeAPIKeyScopeMissing       = "API_KEY_SCOPE_MISSING"
+	CodeAPIKeyNotFound           = "API_KEY_NOT_FOUND"
+	CodeAPIKeyAlreadyRevoked     = "API_KEY_ALREADY_REVOKED"
+	CodeUnknownScope             = "UNKNOWN_SCOPE"
+	CodeExpiryInPast             = "EXPIRY_IN_PAST"
+	CodeSSOLoginFailed           = "SSO_LOGIN_FAILED"
+	CodeSSOStateInvalid          = "SSO_STATE_INVALID"
+	CodeAuthorizationCodeMissing = "AUTHORIZATION_CODE_MISSING"
+	CodeIdentityNotAllowed       = "IDENTITY_NOT_ALLOWED"
+	CodeIdentityConflict         = "IDENTITY_CONFLICT"
+
+	CodeMFATokenInvalid             = "MFA_TOKEN_INVALID"
+	CodeTwoFactorCodeInvalid        = "TWO_FACTOR_CODE_INVALID"
+	CodeTwoFactorAlreadyEnabled     = "TWO_FACTOR_ALREADY_ENABLED"
+	CodeTwoFactorNotEnabled         = "TWO_FACTOR_NOT_ENABLED"
+	CodeTwoFactorRequired           = "TWO_FACTOR_REQUIRED"
+	CodeTwoFactorEnrollmentRequired = "TWO_FACTOR_ENROLLMENT_REQUIRED"
+	CodeTwoFactorEnrollmentMissing  = "TWO_FACTOR_ENROLLMENT_MISSING"
+
+	CodeWeakPassword             = "WEAK_PASSWORD"
+	CodeCurrentPasswordIncorrect = "CURRENT_PASSWORD_INCORRECT"
+	CodePasswordUnchanged        = "PASSWORD_UNCHANGED"
+	CodeResetTokenInvalid        = "RESET_TOKEN_INVALID"
+
+	CodeUserNotFound           = "USER_NOT_FOUND"
+	CodeUsernameTaken          = "USERNAME_TAKEN"
+	CodeEmailTaken             = "EMAIL_TAKEN"
+	CodeUserAlreadyActive      = "USER_ALREADY_ACTIVE"
+	CodeUserAlreadyDeactivated = "USER_ALREADY_DEACTIVATED"
+	CodeUserHasActivity        = "USER_HAS_ACTIVITY"
+	CodeOwnAccount             = "OWN_ACCOUNT"
+	CodeLastActiveAdmin        = "LAST_ACTIVE_ADMIN"
+	CodeLoginAttemptNotFound   = "LOGIN_ATTEMPT_NOT_FOUND"
+
+	CodeCategoryNotFound      = "CATEGORY_NOT_FOUND"
+	CodeCategoryNameTaken     = "CATEGORY_NAME_TAKEN"
+	CodeAttributeNotFound     = "ATTRIBUTE_NOT_FOUND"
+	CodeAttributeNameTaken    = "ATTRIBUTE_NAME_TAKEN"
+	CodeAttributeNameInvalid  = "ATTRIBUTE_NAME_INVALID"
+	CodeAttributeRequired     = "ATTRIBUTE_REQUIRED"
+	CodeAttributeUnknown      = "ATTRIBUTE_UNKNOWN"
+	CodeAttributeValueInvalid = "ATTRIBUTE_VALUE_INVALID"
+
+	CodeItemNotFound        = "ITEM_NOT_FOUND"
+	CodeItemNameTaken       = "ITEM_NAME_TAKEN"
+	CodeItemNotSerialized   = "ITEM_NOT_SERIALIZED"
+	CodeInsufficientStock   = "INSUFFICIENT_STOCK"
+	CodeInvalidQuantity     = "INVALID_QUANTITY"
+	CodeUnitNotFound        = "UNIT_NOT_FOUND"
+	CodeUnitRequired        = "UNIT_REQUIRED"
+	CodeUnitNotInStock      = "UNIT_NOT_IN_STOCK"
+	CodeUnitAssigned        = "UNIT_ASSIGNED"
+	CodeUnitStatusUnchanged = "UNIT_STATUS_UNCHANGED"
+	CodeUnitStatusChanged   = "UNIT_STATUS_CHANGED"
+	CodeSerialNumberTaken   = "SERIAL_NUMBER_TAKEN"
+	CodeAssetTagTaken       = "ASSET_TAG_TAKEN"
+	CodeUnitIdentifierTaken = "UNIT_IDENTIFIER_TAKEN"
+
+	CodeAssignmentNotFound         = "ASSIGNMENT_NOT_FOUND"
+	CodeAssignmentAlreadyCheckedIn = "ASSIGNMENT_ALREADY_CHECKED_IN"
+	CodeDueDateInPast              = "DUE_DATE_IN_PAST"
+
+	CodeAttachmentNotFound = "ATTACHMENT_NOT_FOUND"
+	CodeThumbnailNotFound  = "THUMBNAIL_NOT_FOUND"
+	CodeFileRequired       = "FILE_REQUIRED"
+	CodeFileUnreadable     = "FILE_UNREADABLE"
+	CodeFileTooLarge       = "FILE_TOO_LARGE"
+	CodeFileTypeNotAllowed = "FILE_TYPE_NOT_ALLOWED"
+
+	CodeLabelFormatInvalid = "LABEL_FORMAT_INVALID"
+	CodeLabelCodeInvalid   = "LABEL_CODE_INVALID"
+	CodeCodeNotRecognized  = "CODE_NOT_RECOGNIZED"
+	CodeReportNotFound     = "REPORT_NOT_FOUND"
+)
@@ -1,59 +1,189 @@
 package web
 
-import "net/http"
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
 
 type ErrorResponse interface {
 	Code() int
-	Status() string
+	ErrorCode() string
 	Message() string
+	MessageKey() string
+	Params() []string
+	Errors() []FieldError
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+func NewProblem(errResponse ErrorResponse, detail string, instance string) Problem {
+	return Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(errResponse.Code()),
+		Status:   errResponse.Code(),
+		Detail:   detail,
+		Instance: instance,
+		Code:     errResponse.ErrorCode(),
+		Errors:   errResponse.Errors(),
+	}
 }
 
 type errorResponse struct {
-	ErrCode    int    `json:"code"`
-	ErrStatus  string `json:"status"`
-	ErrMessage string `json:"message"`
+	ErrCode      int
+	ErrErrorCode string
+	ErrMessage   string
+	ErrParams    []string
+	ErrFields    []FieldError
 }
 
 func (e *errorResponse) Code() int {
 	return e.ErrCode
 }
 
-func (e *errorResponse) Status() string {
-	return e.ErrStatus
+func (e *errorResponse) ErrorCode() string {
+	return e.ErrErrorCode
 }
 
 func (e *errorResponse) Message() string {
+	return FormatMessage(e.ErrMessage, e.ErrParams)
+}
+
+func (e *errorResponse) MessageKey() string {
 	return e.ErrMessage
 }
 
-func NewBadRequestError(message string) ErrorResponse {
+func (e *errorResponse) Params() []string {
+	return e.ErrParams
+}
+
+func (e *errorResponse) Errors() []FieldError {
+	return e.ErrFields
+}
+
+func FormatMessage(message string, params []string) string {
+	for i, param := range params {
+		message = strings.ReplaceAll(message, "{"+strconv.Itoa(i)+"}", param)
+	}
+	return message
+}
+
+func NewValidationError(fields []FieldError) ErrorResponse {
+	return &errorResponse{
+		ErrCode:      http.StatusBadRequest,
+		ErrErrorCode: CodeValidationFailed,
+		ErrMessage:   "validation error",
+		ErrFields:    fields,
+	}
+}
+
+func NewBadRequestError(code string, message string, params ...string) ErrorResponse {
+	return &errorResponse{
+		ErrCode:      http.StatusBadRequest,
+		ErrErrorCode: code,
+		ErrMessage:   message,
+		ErrParams:    params,
+	}
+}
+
+func NewUnauthorizedError(code string, message string, params ...string) ErrorResponse {
+	return &errorResponse{
+		ErrCode:      http.StatusUnauthorized,
+		ErrErrorCode: code,
+		ErrMessage:   message,
+		ErrParams:    params,
+	}
+}
+
+func NewForbiddenError(code string, message string, params ...string) ErrorResponse {
+	return &errorResponse{
+		ErrCode:      http.StatusForbidden,
+		ErrErrorCode: code,
+		ErrMessage:   message,
+		ErrParams:    params,
+	}
+}
+
+func NewNotFoundError(code string, message string, params ...string) ErrorResponse {
+	return &errorResponse{
+		ErrCode:      http.StatusNotFound,
+		ErrErrorCode: code,
+		ErrMessage:   message,
+		ErrParams:    params,
+	}
+}
+
+func NewConflictError(code string, message string, params ...string) ErrorResponse {
+	return &errorResponse{
+		ErrCode:      http.StatusConflict,
+		ErrErrorCode: code,
+		ErrMessage:   message,
+		ErrParams:    params,
+	}
+}
+
+func NewRequestEntityTooLargeError(code string, message string, params ...string) ErrorResponse {
+	return &errorResponse{
+		ErrCode:      http.StatusRequestEntityTooLarge,
+		ErrErrorCode: code,
+		ErrMessage:   message,
+		ErrParams:    params,
+	}
+}
+
+func NewUnsupportedMediaTypeError(code string, message string, params ...string) ErrorResponse {
+	return &errorResponse{
+		ErrCode:      http.StatusUnsupportedMediaType,
+		ErrErrorCode: code,
+		ErrMessage:   message,
+		ErrParams:    params,
+	}
+}
+
+func NewTooManyRequestsError(code string, message string, params ...string) ErrorResponse {
 	return &errorResponse{
-		ErrCode:    http.StatusBadRequest,
-		ErrStatus:  "status bad request",
-		ErrMessage: message,
+		ErrCode:      http.StatusTooManyRequests,
+		ErrErrorCode: code,
+		ErrMessage:   message,
+		ErrParams:    params,
 	}
 }
 
-func NewUnauthorizedError(message string) ErrorResponse {
+func NewInternalServerErrorError() ErrorResponse {
 	return &errorResponse{
-		ErrCode:    http.StatusUnauthorized,
-		ErrStatus:  "status unauthorized",
-		ErrMessage: message,
+		ErrCode:      http.StatusInternalServerError,
+		ErrErrorCode: CodeInternalError,
+		ErrMessage:   "internal server error",
 	}
 }
 
-func NewNotFoundError(message string) ErrorResponse {
+func NewServiceUnavailableError(code string, message string, params ...string) ErrorResponse {
 	return &errorResponse{
-		ErrCode:    http.StatusNotFound,
-		ErrStatus:  "status not found",
-		ErrMessage: message,
+		ErrCode:      http.StatusServiceUnavailable,
+		ErrErrorCode: code,
+		ErrMessage:   message,
+		ErrParams:    params,
 	}
 }
 
-func NewInternalServerErrorError(message string) ErrorResponse {
+func NewGatewayTimeoutError() ErrorResponse {
 	return &errorResponse{
-		ErrCode:    http.StatusInternalServerError,
-		ErrStatus:  "status internal server error",
-		ErrMessage: message,
+		ErrCode:      http.StatusGatewayTimeout,
+		ErrErrorCode: CodeDeadlineExceeded,
+		ErrMessage:   "request deadline exceeded",
 	}
 }
@@ -2,23 +2,74 @@ package web
 
 import "time"
 
+const (
+	TokenDeliveryBody   = "body"
+	TokenDeliveryCookie = "cookie"
+)
+
 type UserRegisterRequest struct {
 	FullName string `json:"full_name" validate:"required,max=255"`
 	Username string `json:"username" validate:"required,min=5,max=20"`
-	Password string `json:"password" validate:"required,min=8,max=20"`
-	Role     string `json:"role" validate:"required,eq=admin|eq=user"`
+	Password string `json:"password" validate:"required,max=72"`
+	Role     string `json:"role" validate:"required,oneof=admin user"`
+	Email    string `json:"email" validate:"omitempty,email,max=255"`
 }
 
 type UserLoginRequest struct {
-	Username string `json:"username" validate:"required"`
-	Password string `json:"password" validate:"required"`
+	Username      string `json:"username" validate:"required"`
+	Password      string `json:"password" validate:"required"`
+	TokenDelivery string `json:"token_delivery" validate:"omitempty,oneof=body cookie"`
+}
+
+type TokenRefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type MFAVerifyRequest struct {
+	MFAToken      string `json:"mfa_token" validate:"required"`
+	Code          string `json:"code" validate:"required,max=32"`
+	TokenDelivery string `json:"token_delivery" validate:"omitempty,oneof=body cookie"`
+}
+
+type MFAEnrollRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+}
+
+type TOTPCodeRequest struct {
+	Code string `json:"code" validate:"required,max=32"`
+}
+
+type APIKeyCreateRequest struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,required"`
+	ExpiresAt *time.Time `json:"expires_at"`
 }
 
 type UserUpdateRequest struct {
 	FullName string `json:"full_name" validate:"required,min=1,max=255"`
 	Username string `json:"username" validate:"required,min=5,max=20"`
-	Password string `json:"password" validate:"required,min=8,max=20"`
-	Role     string `json:"role" validate:"required,eq=admin|eq=user"`
+	Password string `json:"password" validate:"required,max=72"`
+	Role     string `json:"role" validate:"required,oneof=admin user"`
+	Email    string `json:"email" validate:"omitempty,email,max=255"`
+}
+
+type ProfileUpdateRequest struct {
+	FullName string `json:"full_name" validate:"required,min=1,max=255"`
+}
+
+type PasswordChangeRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,max=72"`
+	TokenDelivery   string `json:"token_delivery" validate:"omitempty,oneof=body cookie"`
+}
+
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+type PasswordResetConfirmRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,max=72"`
 }
 
 type CategoryAddRequest struct {
@@ -30,21 +81,34 @@ type CategoryUpdateRequest struct {
 	Name string `json:"name" validate:"required,max=255"`
 }
 
+type CategoryAttributeAddRequest struct {
+	CategoryID int      `json:"category_id" validate:"required"`
+	Name       string   `json:"name" validate:"required,max=50"`
+	Label      string   `json:"label" validate:"max=100"`
+	Type       string   `json:"type" validate:"required,oneof=string integer number boolean enum"`
+	Unit       string   `json:"unit" validate:"max=20"`
+	Options    []string `json:"options" validate:"required_if=Type enum,dive,required,max=100"`
+	Required   bool     `json:"required"`
+}
+
 type ItemAddRequest struct {
-	Name          string  `json:"name" validate:"required,max=255"`
-	CategoryID    int     `json:"category_id" validate:"required"`
-	Quantity      int     `json:"quantity" validate:"required"`
-	Price         float64 `json:"price" validate:"required"`
-	Specification string  `json:"specification" validate:"required,max=255"`
+	Name          string         `json:"name" validate:"required,max=255"`
+	CategoryID    int            `json:"category_id" validate:"required"`
+	Quantity      int            `json:"quantity" validate:"required_unless=Serialized true,gte=0"`
+	Price         float64        `json:"price" validate:"required"`
+	Specification string         `json:"specification" validate:"required,max=255"`
+	Serialized    bool           `json:"serialized"`
+	Attributes    map[string]any `json:"attributes"`
 }
 
 type ItemUpdateRequest struct {
-	ID            int     `json:"id" validate:"required"`
-	Name          string  `json:"name" validate:"required,max=255"`
-	CategoryID    int     `json:"category_id" validate:"required"`
-	Quantity      int     `json:"quantity" validate:"required"`
-	Price         float64 `json:"price" validate:"required"`
-	Specification string  `json:"specification" validate:"required,max=255"`
+	ID            int            `json:"id" validate:"required"`
+	Name          string         `json:"name" validate:"required,max=255"`
+	CategoryID    int            `json:"category_id" validate:"required"`
+	Quantity      int            `json:"quantity" validate:"gte=0"`
+	Price         float64        `json:"price" validate:"required"`
+	Specification string         `json:"specification" validate:"required,max=255"`
+	Attributes    map[string]any `json:"attributes"`
 }
 
 type ActivityAddRequest struct {
@@ -54,3 +118,42 @@ type ActivityAddRequest struct {
 	Timestamp     time.Time `json:"timestamp" validate:"required"`
 	PerformedBy   int       `json:"performed_by" validate:"required,max=255"`
 }
+
+type AssignmentCheckOutRequest struct {
+	ItemID   int       `json:"item_id" validate:"required"`
+	Assignee string    `json:"assignee" validate:"required,max=20"`
+	UnitID   int       `json:"unit_id"`
+	Quantity int       `json:"quantity" validate:"required_without=UnitID,gte=0"`
+	DueDate  time.Time `json:"due_date" validate:"required"`
+	Note     string    `json:"note" validate:"max=255"`
+}
+
+type AssignmentCheckInRequest struct {
+	ID   int    `json:"id" validate:"required"`
+	Note string `json:"note" validate:"max=255"`
+}
+
+type ItemUnitAddRequest struct {
+	ItemID       int    `json:"item_id" validate:"required"`
+	SerialNumber string `json:"serial_number" validate:"required,max=100"`
+	AssetTag     string `json:"asset_tag" validate:"required,max=80,printascii"`
+	Note         string `json:"note" validate:"max=255"`
+}
+
+type ItemUnitUpdateRequest struct {
+	ID     int    `json:"id" validate:"required"`
+	Status string `json:"status" validate:"required,oneof=in_stock in_repair retired"`
+	Note   string `json:"note" validate:"max=255"`
+}
+
+type LabelBatchRequest struct {
+	ItemIDs []int `json:"item_ids" validate:"required_without=UnitIDs,max=200"`
+	UnitIDs []int `json:"unit_ids" validate:"required_without=ItemIDs,max=200"`
+}
+
+type AttachmentUploadRequest struct {
+	ItemID   int    `json:"item_id" validate:"required"`
+	Kind     string `json:"kind" validate:"required,oneof=photo datasheet invoice other"`
+	FileName string `json:"file_name" validate:"required,max=255"`
+	Size     int64  `json:"size" validate:"required"`
+}
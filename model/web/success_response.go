@@ -25,6 +25,15 @@ func NewStatusOKData(message string, data any) SuccessResponseData {
 	}
 }
 
+func NewStatusCreatedData(message string, data any) SuccessResponseData {
+	return &successResponseData{
+		ResCode:    http.StatusCreated,
+		ResStatus:  "status created",
+		ResMessage: message,
+		ResData:    data,
+	}
+}
+
 func (s *successResponseData) Code() int {
 	return s.ResCode
 }
@@ -0,0 +1,90 @@
+package password
+
+import (
+	"bufio"
+	_ "embed"
+	"inventory-management-system/config"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxLength is the number of bytes bcrypt hashes; anything past it is ignored.
+const maxLength = 72
+
+//go:embed breached.txt
+var breachedList string
+
+var breached = func() map[string]struct{} {
+	passwords := map[string]struct{}{}
+	scanner := bufio.NewScanner(strings.NewReader(breachedList))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			passwords[strings.ToLower(line)] = struct{}{}
+		}
+	}
+	return passwords
+}()
+
+var ErrBreached = &Error{Message: "password is too common"}
+
+// Error keeps the message template and its parameters apart so callers can translate it.
+type Error struct {
+	Message string
+	Params  []string
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	for i, param := range e.Params {
+		message = strings.ReplaceAll(message, "{"+strconv.Itoa(i)+"}", param)
+	}
+	return message
+}
+
+type Policy struct {
+	MinLength  int
+	MinClasses int
+}
+
+func New(cfg config.Password) Policy {
+	return Policy{MinLength: cfg.MinLength, MinClasses: cfg.MinClasses}
+}
+
+func (p Policy) Check(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return &Error{Message: "password must be at least {0} characters", Params: []string{strconv.Itoa(p.MinLength)}}
+	}
+
+	if len(password) > maxLength {
+		return &Error{Message: "password must be at most {0} bytes", Params: []string{strconv.Itoa(maxLength)}}
+	}
+
+	if classes(password) < p.MinClasses {
+		return &Error{Message: "password must mix at least {0} of lowercase letters, uppercase letters, digits and symbols", Params: []string{strconv.Itoa(p.MinClasses)}}
+	}
+
+	if _, ok := breached[strings.ToLower(password)]; ok {
+		return ErrBreached
+	}
+
+	return nil
+}
+
+func classes(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
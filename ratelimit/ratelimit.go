@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+type Limiter interface {
+	Allow(key string) (bool, time.Duration)
+}
+
+type window struct {
+	count   int
+	resetAt time.Time
+}
+
+type fixedWindowLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	length    time.Duration
+	windows   map[string]*window
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+func NewFixedWindow(limit int, length time.Duration) Limiter {
+	return &fixedWindowLimiter{
+		limit:   limit,
+		length:  length,
+		windows: map[string]*window{},
+		now:     time.Now,
+	}
+}
+
+func (f *fixedWindowLimiter) Allow(key string) (bool, time.Duration) {
+	if f.limit <= 0 || f.length <= 0 {
+		return true, 0
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	f.sweep(now)
+
+	current, ok := f.windows[key]
+	if !ok || !now.Before(current.resetAt) {
+		current = &window{resetAt: now.Add(f.length)}
+		f.windows[key] = current
+	}
+
+	if current.count >= f.limit {
+		return false, current.resetAt.Sub(now)
+	}
+
+	current.count++
+	return true, 0
+}
+
+func (f *fixedWindowLimiter) sweep(now time.Time) {
+	if now.Before(f.nextSweep) {
+		return
+	}
+
+	for key, current := range f.windows {
+		if !now.Before(current.resetAt) {
+			delete(f.windows, key)
+		}
+	}
+	f.nextSweep = now.Add(f.length)
+}
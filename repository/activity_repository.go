@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management-system/logging"
+	"inventory-management-system/model/domain"
+)
+
+type ActivityRepository interface {
+	Create(ctx context.Context, activity *domain.Activities) error
+	FindAll(ctx context.Context) ([]domain.Activities, error)
+	CountByPerformer(ctx context.Context, username string) (int64, error)
+}
+
+type activityRepositoryImpl struct {
+	*gorm.DB
+}
+
+func NewActivityRepository(db *gorm.DB) ActivityRepository {
+	return &activityRepositoryImpl{db}
+}
+
+func (a *activityRepositoryImpl) Create(ctx context.Context, activity *domain.Activities) error {
+	if activity.RequestID == "" {
+		activity.RequestID = logging.RequestID(ctx)
+	}
+
+	return translateError(conn(ctx, a.DB).Create(activity).Error)
+}
+
+func (a *activityRepositoryImpl) FindAll(ctx context.Context) ([]domain.Activities, error) {
+	var activities []domain.Activities
+	err := conn(ctx, a.DB).Order("id").Find(&activities).Error
+	return activities, translateError(err)
+}
+
+func (a *activityRepositoryImpl) CountByPerformer(ctx context.Context, username string) (int64, error) {
+	var count int64
+	err := conn(ctx, a.DB).Model(&domain.Activities{}).Where("performed_by = ?", username).Count(&count).Error
+	return count, translateError(err)
+}
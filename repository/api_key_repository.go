@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management-system/model/domain"
+	"time"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, apiKey *domain.APIKeys) error
+	FindByID(ctx context.Context, apiKeyID int) (domain.APIKeys, error)
+	FindByHash(ctx context.Context, hash string) (domain.APIKeys, error)
+	FindAll(ctx context.Context) ([]domain.APIKeys, error)
+	Revoke(ctx context.Context, apiKeyID int, at time.Time) error
+	TouchLastUsed(ctx context.Context, apiKeyID int, at time.Time) error
+}
+
+type apiKeyRepositoryImpl struct {
+	*gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepositoryImpl{db}
+}
+
+func (a *apiKeyRepositoryImpl) Create(ctx context.Context, apiKey *domain.APIKeys) error {
+	return translateError(conn(ctx, a.DB).Create(apiKey).Error)
+}
+
+func (a *apiKeyRepositoryImpl) FindByID(ctx context.Context, apiKeyID int) (domain.APIKeys, error) {
+	var apiKey domain.APIKeys
+	err := conn(ctx, a.DB).First(&apiKey, apiKeyID).Error
+	return apiKey, translateError(err)
+}
+
+func (a *apiKeyRepositoryImpl) FindByHash(ctx context.Context, hash string) (domain.APIKeys, error) {
+	var apiKey domain.APIKeys
+	err := conn(ctx, a.DB).Where("key_hash = ?", hash).First(&apiKey).Error
+	return apiKey, translateError(err)
+}
+
+func (a *apiKeyRepositoryImpl) FindAll(ctx context.Context) ([]domain.APIKeys, error) {
+	var apiKeys []domain.APIKeys
+	err := conn(ctx, a.DB).Order("id").Find(&apiKeys).Error
+	return apiKeys, translateError(err)
+}
+
+func (a *apiKeyRepositoryImpl) Revoke(ctx context.Context, apiKeyID int, at time.Time) error {
+	return affected(conn(ctx, a.DB).Model(&domain.APIKeys{}).
+		Where("id = ? AND revoked_at IS NULL", apiKeyID).
+		Update("revoked_at", at))
+}
+
+func (a *apiKeyRepositoryImpl) TouchLastUsed(ctx context.Context, apiKeyID int, at time.Time) error {
+	return affected(conn(ctx, a.DB).Model(&domain.APIKeys{}).
+		Where("id = ?", apiKeyID).
+		Update("last_used_at", at))
+}
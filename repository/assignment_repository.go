@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management-system/model/domain"
+	"time"
+)
+
+type AssignmentRepository interface {
+	Create(ctx context.Context, assignment *domain.Assignments) error
+	CheckIn(ctx context.Context, assignmentID int, checkedInBy string, checkedInAt time.Time) error
+	FindByID(ctx context.Context, assignmentID int) (domain.Assignments, error)
+	FindOpen(ctx context.Context) ([]domain.Assignments, error)
+	FindOverdue(ctx context.Context, now time.Time) ([]domain.Assignments, error)
+	FindOpenByAssignee(ctx context.Context, assignee string) ([]domain.Assignments, error)
+}
+
+type assignmentRepositoryImpl struct {
+	*gorm.DB
+}
+
+func NewAssignmentRepository(db *gorm.DB) AssignmentRepository {
+	return &assignmentRepositoryImpl{db}
+}
+
+func (a *assignmentRepositoryImpl) Create(ctx context.Context, assignment *domain.Assignments) error {
+	return translateError(conn(ctx, a.DB).Create(assignment).Error)
+}
+
+func (a *assignmentRepositoryImpl) CheckIn(ctx context.Context, assignmentID int, checkedInBy string, checkedInAt time.Time) error {
+	return affected(conn(ctx, a.DB).Model(&domain.Assignments{}).Where("id = ? AND checked_in_at IS NULL", assignmentID).
+		Updates(&domain.Assignments{CheckedInBy: checkedInBy, CheckedInAt: &checkedInAt}))
+}
+
+func (a *assignmentRepositoryImpl) FindByID(ctx context.Context, assignmentID int) (domain.Assignments, error) {
+	var assignment domain.Assignments
+	err := conn(ctx, a.DB).Where("id = ?", assignmentID).First(&assignment).Error
+	return assignment, translateError(err)
+}
+
+func (a *assignmentRepositoryImpl) FindOpen(ctx context.Context) ([]domain.Assignments, error) {
+	var assignments []domain.Assignments
+	err := conn(ctx, a.DB).Where("checked_in_at IS NULL").Order("due_date").Find(&assignments).Error
+	return assignments, translateError(err)
+}
+
+func (a *assignmentRepositoryImpl) FindOverdue(ctx context.Context, now time.Time) ([]domain.Assignments, error) {
+	var assignments []domain.Assignments
+	err := conn(ctx, a.DB).Where("checked_in_at IS NULL AND due_date < ?", now).Order("due_date").Find(&assignments).Error
+	return assignments, translateError(err)
+}
+
+func (a *assignmentRepositoryImpl) FindOpenByAssignee(ctx context.Context, assignee string) ([]domain.Assignments, error) {
+	var assignments []domain.Assignments
+	err := conn(ctx, a.DB).Where("assignee = ? AND checked_in_at IS NULL", assignee).Order("due_date").Find(&assignments).Error
+	return assignments, translateError(err)
+}
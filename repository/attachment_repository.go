@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management-system/model/domain"
+)
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *domain.Attachments) error
+	Delete(ctx context.Context, attachmentID int) error
+	FindByID(ctx context.Context, attachmentID int) (domain.Attachments, error)
+	FindByItemID(ctx context.Context, itemID int) ([]domain.Attachments, error)
+}
+
+type attachmentRepositoryImpl struct {
+	*gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepositoryImpl{db}
+}
+
+func (a *attachmentRepositoryImpl) Create(ctx context.Context, attachment *domain.Attachments) error {
+	return translateError(conn(ctx, a.DB).Create(attachment).Error)
+}
+
+func (a *attachmentRepositoryImpl) Delete(ctx context.Context, attachmentID int) error {
+	return affected(conn(ctx, a.DB).Where("id = ?", attachmentID).Delete(&domain.Attachments{}))
+}
+
+func (a *attachmentRepositoryImpl) FindByID(ctx context.Context, attachmentID int) (domain.Attachments, error) {
+	var attachment domain.Attachments
+	err := conn(ctx, a.DB).Where("id = ?", attachmentID).First(&attachment).Error
+	return attachment, translateError(err)
+}
+
+func (a *attachmentRepositoryImpl) FindByItemID(ctx context.Context, itemID int) ([]domain.Attachments, error) {
+	var attachments []domain.Attachments
+	err := conn(ctx, a.DB).Where("item_id = ?", itemID).Order("id").Find(&attachments).Error
+	return attachments, translateError(err)
+}
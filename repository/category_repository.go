@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management-system/model/domain"
+)
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *domain.Categories) error
+	Update(ctx context.Context, category *domain.Categories) error
+	Delete(ctx context.Context, categoryID int) error
+	FindByID(ctx context.Context, categoryID int) (domain.Categories, error)
+	FindByName(ctx context.Context, name string) (domain.Categories, error)
+	FindAll(ctx context.Context) ([]domain.Categories, error)
+	CreateAttribute(ctx context.Context, attribute *domain.CategoryAttributes) error
+	DeleteAttribute(ctx context.Context, attributeID int) error
+	FindAttributeByID(ctx context.Context, attributeID int) (domain.CategoryAttributes, error)
+	FindAttributes(ctx context.Context, categoryID int) ([]domain.CategoryAttributes, error)
+}
+
+type categoryRepositoryImpl struct {
+	*gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepositoryImpl{db}
+}
+
+func (c *categoryRepositoryImpl) Create(ctx context.Context, category *domain.Categories) error {
+	return translateError(conn(ctx, c.DB).Create(category).Error)
+}
+
+func (c *categoryRepositoryImpl) Update(ctx context.Context, category *domain.Categories) error {
+	return affected(conn(ctx, c.DB).Model(&domain.Categories{}).Where("id = ?", category.ID).Updates(category))
+}
+
+func (c *categoryRepositoryImpl) Delete(ctx context.Context, categoryID int) error {
+	return affected(conn(ctx, c.DB).Where("id = ?", categoryID).Delete(&domain.Categories{}))
+}
+
+func (c *categoryRepositoryImpl) FindByID(ctx context.Context, categoryID int) (domain.Categories, error) {
+	var category domain.Categories
+	err := conn(ctx, c.DB).Where("id = ?", categoryID).First(&category).Error
+	return category, translateError(err)
+}
+
+func (c *categoryRepositoryImpl) FindByName(ctx context.Context, name string) (domain.Categories, error) {
+	var category domain.Categories
+	err := conn(ctx, c.DB).Where("name = ?", name).First(&category).Error
+	return category, translateError(err)
+}
+
+func (c *categoryRepositoryImpl) FindAll(ctx context.Context) ([]domain.Categories, error) {
+	var categories []domain.Categories
+	err := conn(ctx, c.DB).Order("id").Find(&categories).Error
+	return categories, translateError(err)
+}
+
+func (c *categoryRepositoryImpl) CreateAttribute(ctx context.Context, attribute *domain.CategoryAttributes) error {
+	return translateError(conn(ctx, c.DB).Create(attribute).Error)
+}
+
+func (c *categoryRepositoryImpl) DeleteAttribute(ctx context.Context, attributeID int) error {
+	return conn(ctx, c.DB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("attribute_id = ?", attributeID).Delete(&domain.ItemAttributeValues{}).Error; err != nil {
+			return translateError(err)
+		}
+
+		return affected(tx.Where("id = ?", attributeID).Delete(&domain.CategoryAttributes{}))
+	})
+}
+
+func (c *categoryRepositoryImpl) FindAttributeByID(ctx context.Context, attributeID int) (domain.CategoryAttributes, error) {
+	var attribute domain.CategoryAttributes
+	err := conn(ctx, c.DB).Where("id = ?", attributeID).First(&attribute).Error
+	return attribute, translateError(err)
+}
+
+func (c *categoryRepositoryImpl) FindAttributes(ctx context.Context, categoryID int) ([]domain.CategoryAttributes, error) {
+	var attributes []domain.CategoryAttributes
+	err := conn(ctx, c.DB).Where("category_id = ?", categoryID).Order("id").Find(&attributes).Error
+	return attributes, translateError(err)
+}
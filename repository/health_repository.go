@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+}
+
+type healthRepositoryImpl struct {
+	*gorm.DB
+}
+
+func NewHealthRepository(db *gorm.DB) HealthRepository {
+	return &healthRepositoryImpl{db}
+}
+
+func (h *healthRepositoryImpl) Ping(ctx context.Context) error {
+	db, err := h.DB.DB()
+	if err != nil {
+		return err
+	}
+
+	return db.PingContext(ctx)
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"inventory-management-system/model/domain"
+	"strconv"
+)
+
+type ItemRepository interface {
+	Create(ctx context.Context, item *domain.Items) error
+	Update(ctx context.Context, item *domain.Items) error
+	Delete(ctx context.Context, itemID int) error
+	FindByID(ctx context.Context, itemID int) (domain.Items, error)
+	FindByName(ctx context.Context, name string) (domain.Items, error)
+	FindAll(ctx context.Context) ([]domain.Items, error)
+	FindByAttributes(ctx context.Context, filters []domain.AttributeFilter) ([]domain.Items, error)
+	FindByMaxQuantity(ctx context.Context, quantity int) ([]domain.Items, error)
+	AdjustQuantity(ctx context.Context, itemID int, delta int) error
+	SyncUnitStock(ctx context.Context, itemID int) error
+	FindAttributeValues(ctx context.Context, itemIDs []int) ([]domain.ItemAttributeValues, error)
+	ReplaceAttributeValues(ctx context.Context, itemID int, values []domain.ItemAttributeValues) error
+}
+
+type itemRepositoryImpl struct {
+	*gorm.DB
+}
+
+func NewItemRepository(db *gorm.DB) ItemRepository {
+	return &itemRepositoryImpl{db}
+}
+
+func (i *itemRepositoryImpl) Create(ctx context.Context, item *domain.Items) error {
+	return translateError(conn(ctx, i.DB).Create(item).Error)
+}
+
+func (i *itemRepositoryImpl) Update(ctx context.Context, item *domain.Items) error {
+	return affected(conn(ctx, i.DB).Model(&domain.Items{}).Where("id = ?", item.ID).Updates(item))
+}
+
+func (i *itemRepositoryImpl) Delete(ctx context.Context, itemID int) error {
+	return affected(conn(ctx, i.DB).Where("id = ?", itemID).Delete(&domain.Items{}))
+}
+
+func (i *itemRepositoryImpl) FindByID(ctx context.Context, itemID int) (domain.Items, error) {
+	var item domain.Items
+	err := conn(ctx, i.DB).Where("id = ?", itemID).First(&item).Error
+	return item, translateError(err)
+}
+
+func (i *itemRepositoryImpl) FindByName(ctx context.Context, name string) (domain.Items, error) {
+	var item domain.Items
+	err := conn(ctx, i.DB).Where("name = ?", name).First(&item).Error
+	return item, translateError(err)
+}
+
+func (i *itemRepositoryImpl) FindAll(ctx context.Context) ([]domain.Items, error) {
+	var items []domain.Items
+	err := conn(ctx, i.DB).Order("id").Find(&items).Error
+	return items, translateError(err)
+}
+
+func (i *itemRepositoryImpl) FindByAttributes(ctx context.Context, filters []domain.AttributeFilter) ([]domain.Items, error) {
+	db := conn(ctx, i.DB)
+	query := db.Model(&domain.Items{})
+	for _, filter := range filters {
+		matching := db.Model(&domain.ItemAttributeValues{}).Select("item_id").Where("name = ?", filter.Name)
+		number, err := strconv.ParseFloat(filter.Value, 64)
+		isNumber := err == nil
+
+		switch filter.Operator {
+		case "=":
+			if isNumber {
+				matching = matching.Where("(number_value = ? OR value = ?)", number, filter.Value)
+			} else {
+				matching = matching.Where("value = ?", filter.Value)
+			}
+		case "!=":
+			if isNumber {
+				matching = matching.Where("(number_value IS NULL OR number_value <> ?) AND value <> ?", number, filter.Value)
+			} else {
+				matching = matching.Where("value <> ?", filter.Value)
+			}
+		case ">", ">=", "<", "<=":
+			if !isNumber {
+				return nil, fmt.Errorf("attribute filter %s%s%s needs a numeric value", filter.Name, filter.Operator, filter.Value)
+			}
+			matching = matching.Where("number_value "+filter.Operator+" ?", number)
+		default:
+			return nil, fmt.Errorf("unsupported attribute filter operator %q", filter.Operator)
+		}
+
+		query = query.Where("id IN (?)", matching)
+	}
+
+	var items []domain.Items
+	err := query.Order("id").Find(&items).Error
+	return items, translateError(err)
+}
+
+func (i *itemRepositoryImpl) FindByMaxQuantity(ctx context.Context, quantity int) ([]domain.Items, error) {
+	var items []domain.Items
+	err := conn(ctx, i.DB).Where("quantity <= ?", quantity).Order("id").Find(&items).Error
+	return items, translateError(err)
+}
+
+func (i *itemRepositoryImpl) AdjustQuantity(ctx context.Context, itemID int, delta int) error {
+	result := conn(ctx, i.DB).Model(&domain.Items{}).
+		Where("id = ? AND quantity + ? >= 0", itemID, delta).
+		Update("quantity", gorm.Expr("quantity + ?", delta))
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrInsufficientStock
+	}
+
+	return nil
+}
+
+func (i *itemRepositoryImpl) SyncUnitStock(ctx context.Context, itemID int) error {
+	db := conn(ctx, i.DB)
+	inStock := db.Model(&domain.ItemUnits{}).
+		Select("COUNT(*)").
+		Where("item_id = ? AND status = ?", itemID, domain.UnitStatusInStock)
+
+	return affected(db.Model(&domain.Items{}).Where("id = ?", itemID).Update("quantity", inStock))
+}
+
+func (i *itemRepositoryImpl) FindAttributeValues(ctx context.Context, itemIDs []int) ([]domain.ItemAttributeValues, error) {
+	var values []domain.ItemAttributeValues
+	if len(itemIDs) == 0 {
+		return values, nil
+	}
+
+	err := conn(ctx, i.DB).Where("item_id IN ?", itemIDs).Order("id").Find(&values).Error
+	return values, translateError(err)
+}
+
+func (i *itemRepositoryImpl) ReplaceAttributeValues(ctx context.Context, itemID int, values []domain.ItemAttributeValues) error {
+	db := conn(ctx, i.DB)
+	if err := db.Where("item_id = ?", itemID).Delete(&domain.ItemAttributeValues{}).Error; err != nil {
+		return translateError(err)
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	for index := range values {
+		values[index].ItemID = itemID
+	}
+
+	return translateError(db.Create(&values).Error)
+}
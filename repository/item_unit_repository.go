@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management-system/model/domain"
+)
+
+type ItemUnitRepository interface {
+	Create(ctx context.Context, unit *domain.ItemUnits) error
+	Transition(ctx context.Context, unit *domain.ItemUnits, from string) error
+	FindByID(ctx context.Context, unitID int) (domain.ItemUnits, error)
+	FindByItemID(ctx context.Context, itemID int) ([]domain.ItemUnits, error)
+	FindBySerialNumber(ctx context.Context, serialNumber string) (domain.ItemUnits, error)
+	FindByAssetTag(ctx context.Context, assetTag string) (domain.ItemUnits, error)
+}
+
+type itemUnitRepositoryImpl struct {
+	*gorm.DB
+}
+
+func NewItemUnitRepository(db *gorm.DB) ItemUnitRepository {
+	return &itemUnitRepositoryImpl{db}
+}
+
+func (i *itemUnitRepositoryImpl) Create(ctx context.Context, unit *domain.ItemUnits) error {
+	return translateError(conn(ctx, i.DB).Create(unit).Error)
+}
+
+func (i *itemUnitRepositoryImpl) Transition(ctx context.Context, unit *domain.ItemUnits, from string) error {
+	return affected(conn(ctx, i.DB).Model(&domain.ItemUnits{}).Where("id = ? AND status = ?", unit.ID, from).Updates(unit))
+}
+
+func (i *itemUnitRepositoryImpl) FindByID(ctx context.Context, unitID int) (domain.ItemUnits, error) {
+	var unit domain.ItemUnits
+	err := conn(ctx, i.DB).Where("id = ?", unitID).First(&unit).Error
+	return unit, translateError(err)
+}
+
+func (i *itemUnitRepositoryImpl) FindByItemID(ctx context.Context, itemID int) ([]domain.ItemUnits, error) {
+	var units []domain.ItemUnits
+	err := conn(ctx, i.DB).Where("item_id = ?", itemID).Order("id").Find(&units).Error
+	return units, translateError(err)
+}
+
+func (i *itemUnitRepositoryImpl) FindBySerialNumber(ctx context.Context, serialNumber string) (domain.ItemUnits, error) {
+	var unit domain.ItemUnits
+	err := conn(ctx, i.DB).Where("serial_number = ?", serialNumber).First(&unit).Error
+	return unit, translateError(err)
+}
+
+func (i *itemUnitRepositoryImpl) FindByAssetTag(ctx context.Context, assetTag string) (domain.ItemUnits, error) {
+	var unit domain.ItemUnits
+	err := conn(ctx, i.DB).Where("asset_tag = ?", assetTag).First(&unit).Error
+	return unit, translateError(err)
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management-system/logging"
+	"inventory-management-system/model/domain"
+)
+
+type LoginAttemptRepository interface {
+	Create(ctx context.Context, attempt *domain.LoginAttempts) error
+	FindAll(ctx context.Context, username string) ([]domain.LoginAttempts, error)
+}
+
+type loginAttemptRepositoryImpl struct {
+	*gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptRepository {
+	return &loginAttemptRepositoryImpl{db}
+}
+
+func (l *loginAttemptRepositoryImpl) Create(ctx context.Context, attempt *domain.LoginAttempts) error {
+	if attempt.RequestID == "" {
+		attempt.RequestID = logging.RequestID(ctx)
+	}
+
+	return translateError(conn(ctx, l.DB).Create(attempt).Error)
+}
+
+func (l *loginAttemptRepositoryImpl) FindAll(ctx context.Context, username string) ([]domain.LoginAttempts, error) {
+	var attempts []domain.LoginAttempts
+	db := conn(ctx, l.DB)
+	if username != "" {
+		db = db.Where("username = ?", username)
+	}
+
+	err := db.Order("id").Find(&attempts).Error
+	return attempts, translateError(err)
+}
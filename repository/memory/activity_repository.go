@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"inventory-management-system/logging"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+	"slices"
+)
+
+type activityRepositoryImpl struct {
+	*Store
+}
+
+func NewActivityRepository(store *Store) repository.ActivityRepository {
+	return &activityRepositoryImpl{store}
+}
+
+func (a *activityRepositoryImpl) Create(ctx context.Context, activity *domain.Activities) error {
+	if activity.RequestID == "" {
+		activity.RequestID = logging.RequestID(ctx)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	activity.ID = a.nextID("activities")
+	a.activities = append(a.activities, *activity)
+	return nil
+}
+
+func (a *activityRepositoryImpl) FindAll(ctx context.Context) ([]domain.Activities, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return slices.Clone(a.activities), nil
+}
+
+func (a *activityRepositoryImpl) CountByPerformer(ctx context.Context, username string) (int64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return int64(len(where(a.activities, func(activity domain.Activities) bool { return activity.PerformedBy == username }))), nil
+}
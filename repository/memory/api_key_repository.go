@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+	"slices"
+	"time"
+)
+
+type apiKeyRepositoryImpl struct {
+	*Store
+}
+
+func NewAPIKeyRepository(store *Store) repository.APIKeyRepository {
+	return &apiKeyRepositoryImpl{store}
+}
+
+func (a *apiKeyRepositoryImpl) Create(ctx context.Context, apiKey *domain.APIKeys) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, err := find(a.apiKeys, byKeyHash(apiKey.KeyHash)); err == nil {
+		return repository.ErrDuplicate
+	}
+
+	apiKey.ID = a.nextID("api_keys")
+	apiKey.CreatedAt = time.Now()
+	a.apiKeys = append(a.apiKeys, *apiKey)
+	return nil
+}
+
+func (a *apiKeyRepositoryImpl) FindByID(ctx context.Context, apiKeyID int) (domain.APIKeys, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return find(a.apiKeys, byAPIKeyID(apiKeyID))
+}
+
+func (a *apiKeyRepositoryImpl) FindByHash(ctx context.Context, hash string) (domain.APIKeys, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return find(a.apiKeys, byKeyHash(hash))
+}
+
+func (a *apiKeyRepositoryImpl) FindAll(ctx context.Context) ([]domain.APIKeys, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return slices.Clone(a.apiKeys), nil
+}
+
+func (a *apiKeyRepositoryImpl) Revoke(ctx context.Context, apiKeyID int, at time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	index := slices.IndexFunc(a.apiKeys, byAPIKeyID(apiKeyID))
+	if index < 0 || a.apiKeys[index].RevokedAt != nil {
+		return repository.ErrNotFound
+	}
+
+	a.apiKeys[index].RevokedAt = &at
+	return nil
+}
+
+func (a *apiKeyRepositoryImpl) TouchLastUsed(ctx context.Context, apiKeyID int, at time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	index := slices.IndexFunc(a.apiKeys, byAPIKeyID(apiKeyID))
+	if index < 0 {
+		return repository.ErrNotFound
+	}
+
+	a.apiKeys[index].LastUsedAt = &at
+	return nil
+}
+
+func byAPIKeyID(apiKeyID int) func(domain.APIKeys) bool {
+	return func(apiKey domain.APIKeys) bool { return apiKey.ID == apiKeyID }
+}
+
+func byKeyHash(hash string) func(domain.APIKeys) bool {
+	return func(apiKey domain.APIKeys) bool { return apiKey.KeyHash == hash }
+}
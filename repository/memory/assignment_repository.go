@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+	"slices"
+	"time"
+)
+
+type assignmentRepositoryImpl struct {
+	*Store
+}
+
+func NewAssignmentRepository(store *Store) repository.AssignmentRepository {
+	return &assignmentRepositoryImpl{store}
+}
+
+func (a *assignmentRepositoryImpl) Create(ctx context.Context, assignment *domain.Assignments) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	assignment.ID = a.nextID("assignments")
+	a.assignments = append(a.assignments, *assignment)
+	return nil
+}
+
+func (a *assignmentRepositoryImpl) CheckIn(ctx context.Context, assignmentID int, checkedInBy string, checkedInAt time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return update(a.assignments, func(existing domain.Assignments) bool {
+		return existing.ID == assignmentID && existing.CheckedInAt == nil
+	}, domain.Assignments{CheckedInBy: checkedInBy, CheckedInAt: &checkedInAt})
+}
+
+func (a *assignmentRepositoryImpl) FindByID(ctx context.Context, assignmentID int) (domain.Assignments, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return find(a.assignments, func(assignment domain.Assignments) bool { return assignment.ID == assignmentID })
+}
+
+func (a *assignmentRepositoryImpl) FindOpen(ctx context.Context) ([]domain.Assignments, error) {
+	return a.open(func(domain.Assignments) bool { return true }), nil
+}
+
+func (a *assignmentRepositoryImpl) FindOverdue(ctx context.Context, now time.Time) ([]domain.Assignments, error) {
+	return a.open(func(assignment domain.Assignments) bool { return assignment.DueDate.Before(now) }), nil
+}
+
+func (a *assignmentRepositoryImpl) FindOpenByAssignee(ctx context.Context, assignee string) ([]domain.Assignments, error) {
+	return a.open(func(assignment domain.Assignments) bool { return assignment.Assignee == assignee }), nil
+}
+
+func (a *assignmentRepositoryImpl) open(match func(domain.Assignments) bool) []domain.Assignments {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	assignments := where(a.assignments, func(assignment domain.Assignments) bool {
+		return assignment.CheckedInAt == nil && match(assignment)
+	})
+	slices.SortStableFunc(assignments, func(x, y domain.Assignments) int { return x.DueDate.Compare(y.DueDate) })
+	return assignments
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+	"time"
+)
+
+type attachmentRepositoryImpl struct {
+	*Store
+}
+
+func NewAttachmentRepository(store *Store) repository.AttachmentRepository {
+	return &attachmentRepositoryImpl{store}
+}
+
+func (a *attachmentRepositoryImpl) Create(ctx context.Context, attachment *domain.Attachments) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	attachment.ID = a.nextID("attachments")
+	attachment.CreatedAt = time.Now()
+	a.attachments = append(a.attachments, *attachment)
+	return nil
+}
+
+func (a *attachmentRepositoryImpl) Delete(ctx context.Context, attachmentID int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	attachments, err := remove(a.attachments, func(attachment domain.Attachments) bool { return attachment.ID == attachmentID })
+	a.attachments = attachments
+	return err
+}
+
+func (a *attachmentRepositoryImpl) FindByID(ctx context.Context, attachmentID int) (domain.Attachments, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return find(a.attachments, func(attachment domain.Attachments) bool { return attachment.ID == attachmentID })
+}
+
+func (a *attachmentRepositoryImpl) FindByItemID(ctx context.Context, itemID int) ([]domain.Attachments, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return where(a.attachments, func(attachment domain.Attachments) bool { return attachment.ItemID == itemID }), nil
+}
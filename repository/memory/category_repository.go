@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+	"slices"
+	"time"
+)
+
+type categoryRepositoryImpl struct {
+	*Store
+}
+
+func NewCategoryRepository(store *Store) repository.CategoryRepository {
+	return &categoryRepositoryImpl{store}
+}
+
+func (c *categoryRepositoryImpl) Create(ctx context.Context, category *domain.Categories) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	category.ID = c.nextID("categories")
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = category.CreatedAt
+	c.categories = append(c.categories, *category)
+	return nil
+}
+
+func (c *categoryRepositoryImpl) Update(ctx context.Context, category *domain.Categories) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return update(c.categories, byCategoryID(category.ID), *category)
+}
+
+func (c *categoryRepositoryImpl) Delete(ctx context.Context, categoryID int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	categories, err := remove(c.categories, byCategoryID(categoryID))
+	c.categories = categories
+	return err
+}
+
+func (c *categoryRepositoryImpl) FindByID(ctx context.Context, categoryID int) (domain.Categories, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return find(c.categories, byCategoryID(categoryID))
+}
+
+func (c *categoryRepositoryImpl) FindByName(ctx context.Context, name string) (domain.Categories, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return find(c.categories, func(category domain.Categories) bool { return category.Name == name })
+}
+
+func (c *categoryRepositoryImpl) FindAll(ctx context.Context) ([]domain.Categories, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.categories), nil
+}
+
+func (c *categoryRepositoryImpl) CreateAttribute(ctx context.Context, attribute *domain.CategoryAttributes) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	duplicate := slices.ContainsFunc(c.attributes, func(existing domain.CategoryAttributes) bool {
+		return existing.CategoryID == attribute.CategoryID && existing.Name == attribute.Name
+	})
+	if duplicate {
+		return repository.ErrDuplicate
+	}
+
+	attribute.ID = c.nextID("category_attributes")
+	attribute.CreatedAt = time.Now()
+	attribute.UpdatedAt = attribute.CreatedAt
+	c.attributes = append(c.attributes, *attribute)
+	return nil
+}
+
+func (c *categoryRepositoryImpl) DeleteAttribute(ctx context.Context, attributeID int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	attributes, err := remove(c.attributes, byAttributeID(attributeID))
+	if err != nil {
+		return err
+	}
+
+	c.attributes = attributes
+	c.attributeValues = slices.DeleteFunc(slices.Clone(c.attributeValues), func(value domain.ItemAttributeValues) bool {
+		return value.AttributeID == attributeID
+	})
+	return nil
+}
+
+func (c *categoryRepositoryImpl) FindAttributeByID(ctx context.Context, attributeID int) (domain.CategoryAttributes, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return find(c.attributes, byAttributeID(attributeID))
+}
+
+func (c *categoryRepositoryImpl) FindAttributes(ctx context.Context, categoryID int) ([]domain.CategoryAttributes, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return where(c.attributes, func(attribute domain.CategoryAttributes) bool { return attribute.CategoryID == categoryID }), nil
+}
+
+func byCategoryID(categoryID int) func(domain.Categories) bool {
+	return func(category domain.Categories) bool { return category.ID == categoryID }
+}
+
+func byAttributeID(attributeID int) func(domain.CategoryAttributes) bool {
+	return func(attribute domain.CategoryAttributes) bool { return attribute.ID == attributeID }
+}
@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+	"slices"
+	"strconv"
+	"time"
+)
+
+type itemRepositoryImpl struct {
+	*Store
+}
+
+func NewItemRepository(store *Store) repository.ItemRepository {
+	return &itemRepositoryImpl{store}
+}
+
+func (i *itemRepositoryImpl) Create(ctx context.Context, item *domain.Items) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.nameTaken(item.Name, 0) {
+		return repository.ErrDuplicate
+	}
+
+	item.ID = i.nextID("items")
+	item.CreatedAt = time.Now()
+	item.UpdatedAt = item.CreatedAt
+	i.items = append(i.items, *item)
+	return nil
+}
+
+func (i *itemRepositoryImpl) Update(ctx context.Context, item *domain.Items) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.nameTaken(item.Name, item.ID) {
+		return repository.ErrDuplicate
+	}
+
+	return update(i.items, byItemID(item.ID), *item)
+}
+
+func (i *itemRepositoryImpl) Delete(ctx context.Context, itemID int) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	items, err := remove(i.items, byItemID(itemID))
+	i.items = items
+	return err
+}
+
+func (i *itemRepositoryImpl) FindByID(ctx context.Context, itemID int) (domain.Items, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return find(i.items, byItemID(itemID))
+}
+
+func (i *itemRepositoryImpl) FindByName(ctx context.Context, name string) (domain.Items, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return find(i.items, func(item domain.Items) bool { return item.Name == name })
+}
+
+func (i *itemRepositoryImpl) FindAll(ctx context.Context) ([]domain.Items, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return slices.Clone(i.items), nil
+}
+
+func (i *itemRepositoryImpl) FindByAttributes(ctx context.Context, filters []domain.AttributeFilter) ([]domain.Items, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, attributeFilter := range filters {
+		if _, err := strconv.ParseFloat(attributeFilter.Value, 64); err != nil && attributeFilter.Operator != "=" && attributeFilter.Operator != "!=" {
+			return nil, fmt.Errorf("attribute filter %s%s%s needs a numeric value", attributeFilter.Name, attributeFilter.Operator, attributeFilter.Value)
+		}
+	}
+
+	return where(i.items, func(item domain.Items) bool {
+		for _, attributeFilter := range filters {
+			matched := slices.ContainsFunc(i.attributeValues, func(value domain.ItemAttributeValues) bool {
+				return value.ItemID == item.ID && value.Name == attributeFilter.Name && matches(value, attributeFilter)
+			})
+			if !matched {
+				return false
+			}
+		}
+		return true
+	}), nil
+}
+
+func (i *itemRepositoryImpl) FindByMaxQuantity(ctx context.Context, quantity int) ([]domain.Items, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return where(i.items, func(item domain.Items) bool { return item.Quantity <= quantity }), nil
+}
+
+func (i *itemRepositoryImpl) AdjustQuantity(ctx context.Context, itemID int, delta int) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	index := slices.IndexFunc(i.items, func(item domain.Items) bool {
+		return item.ID == itemID && item.Quantity+delta >= 0
+	})
+	if index < 0 {
+		return repository.ErrInsufficientStock
+	}
+
+	i.items[index].Quantity += delta
+	return nil
+}
+
+func (i *itemRepositoryImpl) SyncUnitStock(ctx context.Context, itemID int) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	index := slices.IndexFunc(i.items, byItemID(itemID))
+	if index < 0 {
+		return repository.ErrNotFound
+	}
+
+	inStock := where(i.units, func(unit domain.ItemUnits) bool {
+		return unit.ItemID == itemID && unit.Status == domain.UnitStatusInStock
+	})
+	i.items[index].Quantity = len(inStock)
+	return nil
+}
+
+func (i *itemRepositoryImpl) FindAttributeValues(ctx context.Context, itemIDs []int) ([]domain.ItemAttributeValues, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return where(i.attributeValues, func(value domain.ItemAttributeValues) bool {
+		return slices.Contains(itemIDs, value.ItemID)
+	}), nil
+}
+
+func (i *itemRepositoryImpl) ReplaceAttributeValues(ctx context.Context, itemID int, values []domain.ItemAttributeValues) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.attributeValues = slices.DeleteFunc(slices.Clone(i.attributeValues), func(value domain.ItemAttributeValues) bool {
+		return value.ItemID == itemID
+	})
+	for index := range values {
+		values[index].ID = i.nextID("item_attribute_values")
+		values[index].ItemID = itemID
+		i.attributeValues = append(i.attributeValues, values[index])
+	}
+
+	return nil
+}
+
+func byItemID(itemID int) func(domain.Items) bool {
+	return func(item domain.Items) bool { return item.ID == itemID }
+}
+
+func matches(value domain.ItemAttributeValues, attributeFilter domain.AttributeFilter) bool {
+	number, err := strconv.ParseFloat(attributeFilter.Value, 64)
+	isNumber := err == nil
+	hasNumber := value.NumberValue != nil
+
+	switch attributeFilter.Operator {
+	case "=":
+		return value.Value == attributeFilter.Value || (isNumber && hasNumber && *value.NumberValue == number)
+	case "!=":
+		return value.Value != attributeFilter.Value && (!isNumber || !hasNumber || *value.NumberValue != number)
+	case ">":
+		return hasNumber && *value.NumberValue > number
+	case ">=":
+		return hasNumber && *value.NumberValue >= number
+	case "<":
+		return hasNumber && *value.NumberValue < number
+	case "<=":
+		return hasNumber && *value.NumberValue <= number
+	default:
+		return false
+	}
+}
+
+func (i *itemRepositoryImpl) nameTaken(name string, itemID int) bool {
+	return name != "" && slices.ContainsFunc(i.items, func(item domain.Items) bool {
+		return item.Name == name && item.ID != itemID
+	})
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+	"slices"
+	"time"
+)
+
+type itemUnitRepositoryImpl struct {
+	*Store
+}
+
+func NewItemUnitRepository(store *Store) repository.ItemUnitRepository {
+	return &itemUnitRepositoryImpl{store}
+}
+
+func (i *itemUnitRepositoryImpl) Create(ctx context.Context, unit *domain.ItemUnits) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	duplicate := slices.ContainsFunc(i.units, func(existing domain.ItemUnits) bool {
+		return existing.SerialNumber == unit.SerialNumber || existing.AssetTag == unit.AssetTag
+	})
+	if duplicate {
+		return repository.ErrDuplicate
+	}
+
+	unit.ID = i.nextID("item_units")
+	unit.CreatedAt = time.Now()
+	unit.UpdatedAt = unit.CreatedAt
+	i.units = append(i.units, *unit)
+	return nil
+}
+
+func (i *itemUnitRepositoryImpl) Transition(ctx context.Context, unit *domain.ItemUnits, from string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return update(i.units, func(existing domain.ItemUnits) bool {
+		return existing.ID == unit.ID && existing.Status == from
+	}, *unit)
+}
+
+func (i *itemUnitRepositoryImpl) FindByID(ctx context.Context, unitID int) (domain.ItemUnits, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return find(i.units, func(unit domain.ItemUnits) bool { return unit.ID == unitID })
+}
+
+func (i *itemUnitRepositoryImpl) FindByItemID(ctx context.Context, itemID int) ([]domain.ItemUnits, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return where(i.units, func(unit domain.ItemUnits) bool { return unit.ItemID == itemID }), nil
+}
+
+func (i *itemUnitRepositoryImpl) FindBySerialNumber(ctx context.Context, serialNumber string) (domain.ItemUnits, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return find(i.units, func(unit domain.ItemUnits) bool { return unit.SerialNumber == serialNumber })
+}
+
+func (i *itemUnitRepositoryImpl) FindByAssetTag(ctx context.Context, assetTag string) (domain.ItemUnits, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return find(i.units, func(unit domain.ItemUnits) bool { return unit.AssetTag == assetTag })
+}
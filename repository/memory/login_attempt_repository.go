@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"inventory-management-system/logging"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+)
+
+type loginAttemptRepositoryImpl struct {
+	*Store
+}
+
+func NewLoginAttemptRepository(store *Store) repository.LoginAttemptRepository {
+	return &loginAttemptRepositoryImpl{store}
+}
+
+func (l *loginAttemptRepositoryImpl) Create(ctx context.Context, attempt *domain.LoginAttempts) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if attempt.RequestID == "" {
+		attempt.RequestID = logging.RequestID(ctx)
+	}
+
+	attempt.ID = l.nextID("login_attempts")
+	l.loginAttempts = append(l.loginAttempts, *attempt)
+	return nil
+}
+
+func (l *loginAttemptRepositoryImpl) FindAll(ctx context.Context, username string) ([]domain.LoginAttempts, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return where(l.loginAttempts, func(attempt domain.LoginAttempts) bool {
+		return username == "" || attempt.Username == username
+	}), nil
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+	"time"
+)
+
+type passwordResetRepositoryImpl struct {
+	*Store
+}
+
+func NewPasswordResetRepository(store *Store) repository.PasswordResetRepository {
+	return &passwordResetRepositoryImpl{store}
+}
+
+func (p *passwordResetRepositoryImpl) Create(ctx context.Context, passwordReset *domain.PasswordResets) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := find(p.passwordResets, byResetTokenHash(passwordReset.TokenHash)); err == nil {
+		return repository.ErrDuplicate
+	}
+
+	passwordReset.ID = p.nextID("password_resets")
+	passwordReset.CreatedAt = time.Now()
+	p.passwordResets = append(p.passwordResets, *passwordReset)
+	return nil
+}
+
+func (p *passwordResetRepositoryImpl) FindByTokenHash(ctx context.Context, hash string) (domain.PasswordResets, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return find(p.passwordResets, byResetTokenHash(hash))
+}
+
+func (p *passwordResetRepositoryImpl) MarkUsed(ctx context.Context, passwordResetID int, at time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i := range p.passwordResets {
+		if p.passwordResets[i].ID == passwordResetID && p.passwordResets[i].UsedAt == nil {
+			p.passwordResets[i].UsedAt = &at
+			return nil
+		}
+	}
+
+	return repository.ErrNotFound
+}
+
+func (p *passwordResetRepositoryImpl) InvalidateByUsername(ctx context.Context, username string, at time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i := range p.passwordResets {
+		if p.passwordResets[i].Username == username && p.passwordResets[i].UsedAt == nil {
+			p.passwordResets[i].UsedAt = &at
+		}
+	}
+
+	return nil
+}
+
+func byResetTokenHash(hash string) func(domain.PasswordResets) bool {
+	return func(passwordReset domain.PasswordResets) bool { return passwordReset.TokenHash == hash }
+}
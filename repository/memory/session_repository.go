@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+	"time"
+)
+
+type sessionRepositoryImpl struct {
+	*Store
+}
+
+func NewSessionRepository(store *Store) repository.SessionRepository {
+	return &sessionRepositoryImpl{store}
+}
+
+func (s *sessionRepositoryImpl) Create(ctx context.Context, session *domain.Sessions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := find(s.sessions, byToken(session.Token)); err == nil {
+		return repository.ErrDuplicate
+	}
+
+	session.ID = uint(s.nextID("sessions"))
+	session.CreatedAt = time.Now()
+	session.UpdatedAt = session.CreatedAt
+	s.sessions = append(s.sessions, *session)
+	return nil
+}
+
+func (s *sessionRepositoryImpl) FindByToken(ctx context.Context, token string) (domain.Sessions, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return find(s.sessions, byToken(token))
+}
+
+func (s *sessionRepositoryImpl) DeleteByToken(ctx context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, err := remove(s.sessions, byToken(token))
+	s.sessions = sessions
+	return err
+}
+
+func (s *sessionRepositoryImpl) FindByRefreshTokenHash(ctx context.Context, hash string) (domain.Sessions, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return find(s.sessions, func(session domain.Sessions) bool { return session.RefreshTokenHash == hash })
+}
+
+func (s *sessionRepositoryImpl) MarkRotated(ctx context.Context, id uint, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.sessions {
+		if s.sessions[i].ID == id && s.sessions[i].RotatedAt == nil && s.sessions[i].RevokedAt == nil {
+			s.sessions[i].RotatedAt = &at
+			return nil
+		}
+	}
+
+	return repository.ErrNotFound
+}
+
+func (s *sessionRepositoryImpl) RevokeFamily(ctx context.Context, familyID string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.sessions {
+		if s.sessions[i].FamilyID == familyID && s.sessions[i].RevokedAt == nil {
+			s.sessions[i].RevokedAt = &at
+		}
+	}
+
+	return nil
+}
+
+func (s *sessionRepositoryImpl) RevokeByUsername(ctx context.Context, username string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.sessions {
+		if s.sessions[i].Username == username && s.sessions[i].RevokedAt == nil {
+			s.sessions[i].RevokedAt = &at
+		}
+	}
+
+	return nil
+}
+
+func byToken(token string) func(domain.Sessions) bool {
+	return func(session domain.Sessions) bool { return session.Token == token }
+}
@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+type Store struct {
+	mu              sync.Mutex
+	tx              sync.Mutex
+	users           []domain.Users
+	sessions        []domain.Sessions
+	items           []domain.Items
+	categories      []domain.Categories
+	activities      []domain.Activities
+	assignments     []domain.Assignments
+	units           []domain.ItemUnits
+	attachments     []domain.Attachments
+	attributes      []domain.CategoryAttributes
+	attributeValues []domain.ItemAttributeValues
+	loginAttempts   []domain.LoginAttempts
+	apiKeys         []domain.APIKeys
+	passwordResets  []domain.PasswordResets
+	sequences       map[string]int
+}
+
+func NewStore() *Store {
+	return &Store{sequences: map[string]int{}}
+}
+
+func (s *Store) nextID(table string) int {
+	s.sequences[table]++
+	return s.sequences[table]
+}
+
+func (s *Store) snapshot() *Store {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sequences := make(map[string]int, len(s.sequences))
+	for table, id := range s.sequences {
+		sequences[table] = id
+	}
+
+	return &Store{
+		users:           slices.Clone(s.users),
+		sessions:        slices.Clone(s.sessions),
+		items:           slices.Clone(s.items),
+		categories:      slices.Clone(s.categories),
+		activities:      slices.Clone(s.activities),
+		assignments:     slices.Clone(s.assignments),
+		units:           slices.Clone(s.units),
+		attachments:     slices.Clone(s.attachments),
+		attributes:      slices.Clone(s.attributes),
+		attributeValues: slices.Clone(s.attributeValues),
+		loginAttempts:   slices.Clone(s.loginAttempts),
+		apiKeys:         slices.Clone(s.apiKeys),
+		passwordResets:  slices.Clone(s.passwordResets),
+		sequences:       sequences,
+	}
+}
+
+func (s *Store) restore(snapshot *Store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users = snapshot.users
+	s.sessions = snapshot.sessions
+	s.items = snapshot.items
+	s.categories = snapshot.categories
+	s.activities = snapshot.activities
+	s.assignments = snapshot.assignments
+	s.units = snapshot.units
+	s.attachments = snapshot.attachments
+	s.attributes = snapshot.attributes
+	s.attributeValues = snapshot.attributeValues
+	s.loginAttempts = snapshot.loginAttempts
+	s.apiKeys = snapshot.apiKeys
+	s.passwordResets = snapshot.passwordResets
+	s.sequences = snapshot.sequences
+}
+
+type transactionKey struct{}
+
+type transactorImpl struct {
+	store *Store
+}
+
+func NewTransactor(store *Store) repository.Transactor {
+	return &transactorImpl{store}
+}
+
+func (t *transactorImpl) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(transactionKey{}) == nil {
+		t.store.tx.Lock()
+		defer t.store.tx.Unlock()
+		ctx = context.WithValue(ctx, transactionKey{}, true)
+	}
+
+	snapshot := t.store.snapshot()
+	if err := fn(ctx); err != nil {
+		t.store.restore(snapshot)
+		return err
+	}
+
+	return nil
+}
+
+func find[T any](records []T, match func(T) bool) (T, error) {
+	for _, record := range records {
+		if match(record) {
+			return record, nil
+		}
+	}
+
+	var zero T
+	return zero, repository.ErrNotFound
+}
+
+func where[T any](records []T, match func(T) bool) []T {
+	result := []T{}
+	for _, record := range records {
+		if match(record) {
+			result = append(result, record)
+		}
+	}
+
+	return result
+}
+
+func update[T any](records []T, match func(T) bool, changes T) error {
+	index := slices.IndexFunc(records, match)
+	if index < 0 {
+		return repository.ErrNotFound
+	}
+
+	target := reflect.ValueOf(&records[index]).Elem()
+	source := reflect.ValueOf(changes)
+	for i := 0; i < source.NumField(); i++ {
+		if !source.Field(i).IsZero() {
+			target.Field(i).Set(source.Field(i))
+		}
+	}
+
+	return nil
+}
+
+func remove[T any](records []T, match func(T) bool) ([]T, error) {
+	remaining := slices.DeleteFunc(slices.Clone(records), match)
+	if len(remaining) == len(records) {
+		return records, repository.ErrNotFound
+	}
+
+	return remaining, nil
+}
@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"context"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+	"slices"
+	"time"
+)
+
+type userRepositoryImpl struct {
+	*Store
+}
+
+func NewUserRepository(store *Store) repository.UserRepository {
+	return &userRepositoryImpl{store}
+}
+
+func (u *userRepositoryImpl) Create(ctx context.Context, user *domain.Users) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, err := find(u.users, byUsername(user.Username)); err == nil {
+		return repository.ErrDuplicate
+	}
+	if u.emailTaken(user.Email, user.Username) {
+		return repository.ErrDuplicate
+	}
+
+	user.ID = uint(u.nextID("users"))
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = user.CreatedAt
+	u.users = append(u.users, *user)
+	return nil
+}
+
+func (u *userRepositoryImpl) Update(ctx context.Context, user *domain.Users) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.emailTaken(user.Email, user.Username) {
+		return repository.ErrDuplicate
+	}
+
+	return update(u.users, byUsername(user.Username), *user)
+}
+
+func (u *userRepositoryImpl) Delete(ctx context.Context, username string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	users, err := remove(u.users, byUsername(username))
+	u.users = users
+	return err
+}
+
+func (u *userRepositoryImpl) FindByUsername(ctx context.Context, username string) (domain.Users, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return find(u.users, byUsername(username))
+}
+
+func (u *userRepositoryImpl) FindByEmail(ctx context.Context, email string) (domain.Users, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return find(u.users, func(user domain.Users) bool { return user.Email != nil && *user.Email == email })
+}
+
+func (u *userRepositoryImpl) FindByOIDCSubject(ctx context.Context, subject string) (domain.Users, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return find(u.users, func(user domain.Users) bool { return user.OIDCSubject != nil && *user.OIDCSubject == subject })
+}
+
+func (u *userRepositoryImpl) FindAll(ctx context.Context) ([]domain.Users, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return slices.Clone(u.users), nil
+}
+
+func (u *userRepositoryImpl) IncrementFailedLogins(ctx context.Context, username string) (int, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	index := slices.IndexFunc(u.users, byUsername(username))
+	if index < 0 {
+		return 0, repository.ErrNotFound
+	}
+
+	u.users[index].FailedLogins++
+	return u.users[index].FailedLogins, nil
+}
+
+func (u *userRepositoryImpl) SetLoginState(ctx context.Context, username string, failedLogins int, lockedUntil *time.Time) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	index := slices.IndexFunc(u.users, byUsername(username))
+	if index < 0 {
+		return repository.ErrNotFound
+	}
+
+	u.users[index].FailedLogins = failedLogins
+	u.users[index].LockedUntil = lockedUntil
+	return nil
+}
+
+func (u *userRepositoryImpl) SetIdentity(ctx context.Context, username string, subject string, email *string, role string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	index := slices.IndexFunc(u.users, byUsername(username))
+	if index < 0 {
+		return repository.ErrNotFound
+	}
+	if u.emailTaken(email, username) {
+		return repository.ErrDuplicate
+	}
+
+	u.users[index].OIDCSubject = &subject
+	u.users[index].Email = email
+	u.users[index].Role = role
+	return nil
+}
+
+func (u *userRepositoryImpl) SetTwoFactor(ctx context.Context, username string, twoFactor domain.TwoFactor) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	index := slices.IndexFunc(u.users, byUsername(username))
+	if index < 0 {
+		return repository.ErrNotFound
+	}
+
+	u.users[index].TwoFactor = twoFactor
+	return nil
+}
+
+func (u *userRepositoryImpl) UseTOTPStep(ctx context.Context, username string, step int64) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	index := slices.IndexFunc(u.users, byUsername(username))
+	if index < 0 || u.users[index].TOTPLastStep >= step {
+		return repository.ErrNotFound
+	}
+
+	u.users[index].TOTPLastStep = step
+	return nil
+}
+
+func (u *userRepositoryImpl) ReplaceRecoveryCodes(ctx context.Context, username string, current []string, remaining []string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	index := slices.IndexFunc(u.users, byUsername(username))
+	if index < 0 || !slices.Equal(u.users[index].RecoveryCodes, current) {
+		return repository.ErrNotFound
+	}
+
+	u.users[index].RecoveryCodes = slices.Clone(remaining)
+	return nil
+}
+
+func (u *userRepositoryImpl) SetActive(ctx context.Context, username string, active bool) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	index := slices.IndexFunc(u.users, byUsername(username))
+	if index < 0 {
+		return repository.ErrNotFound
+	}
+
+	u.users[index].Active = active
+	return nil
+}
+
+func (u *userRepositoryImpl) LockActiveByRole(ctx context.Context, role string) (int64, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return int64(len(where(u.users, func(user domain.Users) bool { return user.Role == role && user.Active }))), nil
+}
+
+func (u *userRepositoryImpl) emailTaken(email *string, username string) bool {
+	return email != nil && slices.ContainsFunc(u.users, func(user domain.Users) bool {
+		return user.Email != nil && *user.Email == *email && user.Username != username
+	})
+}
+
+func byUsername(username string) func(domain.Users) bool {
+	return func(user domain.Users) bool { return user.Username == username }
+}
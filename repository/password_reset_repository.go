@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management-system/model/domain"
+	"time"
+)
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, passwordReset *domain.PasswordResets) error
+	FindByTokenHash(ctx context.Context, hash string) (domain.PasswordResets, error)
+	MarkUsed(ctx context.Context, passwordResetID int, at time.Time) error
+	InvalidateByUsername(ctx context.Context, username string, at time.Time) error
+}
+
+type passwordResetRepositoryImpl struct {
+	*gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepositoryImpl{db}
+}
+
+func (p *passwordResetRepositoryImpl) Create(ctx context.Context, passwordReset *domain.PasswordResets) error {
+	return translateError(conn(ctx, p.DB).Create(passwordReset).Error)
+}
+
+func (p *passwordResetRepositoryImpl) FindByTokenHash(ctx context.Context, hash string) (domain.PasswordResets, error) {
+	var passwordReset domain.PasswordResets
+	err := conn(ctx, p.DB).Where("token_hash = ?", hash).First(&passwordReset).Error
+	return passwordReset, translateError(err)
+}
+
+func (p *passwordResetRepositoryImpl) MarkUsed(ctx context.Context, passwordResetID int, at time.Time) error {
+	return affected(conn(ctx, p.DB).Model(&domain.PasswordResets{}).
+		Where("id = ? AND used_at IS NULL", passwordResetID).
+		Update("used_at", at))
+}
+
+func (p *passwordResetRepositoryImpl) InvalidateByUsername(ctx context.Context, username string, at time.Time) error {
+	return translateError(conn(ctx, p.DB).Model(&domain.PasswordResets{}).
+		Where("username = ? AND used_at IS NULL", username).
+		Update("used_at", at).Error)
+}
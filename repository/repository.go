@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotFound          = errors.New("record not found")
+	ErrDuplicate         = errors.New("duplicate record")
+	ErrInsufficientStock = errors.New("insufficient stock")
+)
+
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type transactionKey struct{}
+
+type transactorImpl struct {
+	*gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) Transactor {
+	return &transactorImpl{db}
+}
+
+func (t *transactorImpl) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return conn(ctx, t.DB).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, transactionKey{}, tx))
+	})
+}
+
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx
+	}
+
+	return db.WithContext(ctx)
+}
+
+func translateError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrDuplicate
+	default:
+		return err
+	}
+}
+
+func affected(result *gorm.DB) error {
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management-system/model/domain"
+	"time"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *domain.Sessions) error
+	FindByToken(ctx context.Context, token string) (domain.Sessions, error)
+	DeleteByToken(ctx context.Context, token string) error
+	FindByRefreshTokenHash(ctx context.Context, hash string) (domain.Sessions, error)
+	MarkRotated(ctx context.Context, id uint, at time.Time) error
+	RevokeFamily(ctx context.Context, familyID string, at time.Time) error
+	RevokeByUsername(ctx context.Context, username string, at time.Time) error
+}
+
+type sessionRepositoryImpl struct {
+	*gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepositoryImpl{db}
+}
+
+func (s *sessionRepositoryImpl) Create(ctx context.Context, session *domain.Sessions) error {
+	return translateError(conn(ctx, s.DB).Create(session).Error)
+}
+
+func (s *sessionRepositoryImpl) FindByToken(ctx context.Context, token string) (domain.Sessions, error) {
+	var session domain.Sessions
+	err := conn(ctx, s.DB).Where("token = ?", token).First(&session).Error
+	return session, translateError(err)
+}
+
+func (s *sessionRepositoryImpl) DeleteByToken(ctx context.Context, token string) error {
+	return affected(conn(ctx, s.DB).Where("token = ?", token).Delete(&domain.Sessions{}))
+}
+
+func (s *sessionRepositoryImpl) FindByRefreshTokenHash(ctx context.Context, hash string) (domain.Sessions, error) {
+	var session domain.Sessions
+	err := conn(ctx, s.DB).Where("refresh_token_hash = ?", hash).First(&session).Error
+	return session, translateError(err)
+}
+
+func (s *sessionRepositoryImpl) MarkRotated(ctx context.Context, id uint, at time.Time) error {
+	return affected(conn(ctx, s.DB).Model(&domain.Sessions{}).
+		Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", id).
+		Update("rotated_at", at))
+}
+
+func (s *sessionRepositoryImpl) RevokeFamily(ctx context.Context, familyID string, at time.Time) error {
+	return translateError(conn(ctx, s.DB).Model(&domain.Sessions{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", at).Error)
+}
+
+func (s *sessionRepositoryImpl) RevokeByUsername(ctx context.Context, username string, at time.Time) error {
+	return translateError(conn(ctx, s.DB).Model(&domain.Sessions{}).
+		Where("username = ? AND revoked_at IS NULL", username).
+		Update("revoked_at", at).Error)
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"inventory-management-system/model/domain"
+	"time"
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, user *domain.Users) error
+	Update(ctx context.Context, user *domain.Users) error
+	Delete(ctx context.Context, username string) error
+	FindByUsername(ctx context.Context, username string) (domain.Users, error)
+	FindByEmail(ctx context.Context, email string) (domain.Users, error)
+	FindByOIDCSubject(ctx context.Context, subject string) (domain.Users, error)
+	FindAll(ctx context.Context) ([]domain.Users, error)
+	IncrementFailedLogins(ctx context.Context, username string) (int, error)
+	SetLoginState(ctx context.Context, username string, failedLogins int, lockedUntil *time.Time) error
+	SetIdentity(ctx context.Context, username string, subject string, email *string, role string) error
+	SetTwoFactor(ctx context.Context, username string, twoFactor domain.TwoFactor) error
+	UseTOTPStep(ctx context.Context, username string, step int64) error
+	ReplaceRecoveryCodes(ctx context.Context, username string, current []string, remaining []string) error
+	SetActive(ctx context.Context, username string, active bool) error
+	LockActiveByRole(ctx context.Context, role string) (int64, error)
+}
+
+type userRepositoryImpl struct {
+	*gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepositoryImpl{db}
+}
+
+func (u *userRepositoryImpl) Create(ctx context.Context, user *domain.Users) error {
+	return translateError(conn(ctx, u.DB).Create(user).Error)
+}
+
+func (u *userRepositoryImpl) Update(ctx context.Context, user *domain.Users) error {
+	return affected(conn(ctx, u.DB).Model(&domain.Users{}).Where("username = ?", user.Username).Updates(user))
+}
+
+func (u *userRepositoryImpl) Delete(ctx context.Context, username string) error {
+	return affected(conn(ctx, u.DB).Where("username = ?", username).Delete(&domain.Users{}))
+}
+
+func (u *userRepositoryImpl) FindByUsername(ctx context.Context, username string) (domain.Users, error) {
+	var user domain.Users
+	err := conn(ctx, u.DB).Where("username = ?", username).First(&user).Error
+	return user, translateError(err)
+}
+
+func (u *userRepositoryImpl) FindByEmail(ctx context.Context, email string) (domain.Users, error) {
+	var user domain.Users
+	err := conn(ctx, u.DB).Where("email = ?", email).Order("id").First(&user).Error
+	return user, translateError(err)
+}
+
+func (u *userRepositoryImpl) FindByOIDCSubject(ctx context.Context, subject string) (domain.Users, error) {
+	var user domain.Users
+	err := conn(ctx, u.DB).Where("oidc_subject = ?", subject).First(&user).Error
+	return user, translateError(err)
+}
+
+func (u *userRepositoryImpl) FindAll(ctx context.Context) ([]domain.Users, error) {
+	var users []domain.Users
+	err := conn(ctx, u.DB).Order("id").Find(&users).Error
+	return users, translateError(err)
+}
+
+func (u *userRepositoryImpl) IncrementFailedLogins(ctx context.Context, username string) (int, error) {
+	db := conn(ctx, u.DB)
+	err := affected(db.Model(&domain.Users{}).Where("username = ?", username).
+		UpdateColumn("failed_logins", gorm.Expr("failed_logins + 1")))
+	if err != nil {
+		return 0, err
+	}
+
+	var user domain.Users
+	err = db.Select("failed_logins").Where("username = ?", username).First(&user).Error
+	return user.FailedLogins, translateError(err)
+}
+
+func (u *userRepositoryImpl) SetLoginState(ctx context.Context, username string, failedLogins int, lockedUntil *time.Time) error {
+	return affected(conn(ctx, u.DB).Model(&domain.Users{}).Where("username = ?", username).
+		UpdateColumns(map[string]any{"failed_logins": failedLogins, "locked_until": lockedUntil}))
+}
+
+func (u *userRepositoryImpl) SetIdentity(ctx context.Context, username string, subject string, email *string, role string) error {
+	return affected(conn(ctx, u.DB).Model(&domain.Users{}).Where("username = ?", username).
+		UpdateColumns(map[string]any{"oidc_subject": subject, "email": email, "role": role}))
+}
+
+func (u *userRepositoryImpl) SetTwoFactor(ctx context.Context, username string, twoFactor domain.TwoFactor) error {
+	return affected(conn(ctx, u.DB).Model(&domain.Users{}).Where("username = ?", username).
+		Select("totp_secret", "totp_enabled", "totp_last_step", "recovery_codes").Updates(&domain.Users{TwoFactor: twoFactor}))
+}
+
+func (u *userRepositoryImpl) UseTOTPStep(ctx context.Context, username string, step int64) error {
+	return affected(conn(ctx, u.DB).Model(&domain.Users{}).Where("username = ? AND totp_last_step < ?", username, step).
+		UpdateColumn("totp_last_step", step))
+}
+
+func (u *userRepositoryImpl) ReplaceRecoveryCodes(ctx context.Context, username string, current []string, remaining []string) error {
+	encoded, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	return affected(conn(ctx, u.DB).Model(&domain.Users{}).Where("username = ? AND recovery_codes = ?", username, string(encoded)).
+		Select("recovery_codes").Updates(&domain.Users{TwoFactor: domain.TwoFactor{RecoveryCodes: remaining}}))
+}
+
+func (u *userRepositoryImpl) SetActive(ctx context.Context, username string, active bool) error {
+	return affected(conn(ctx, u.DB).Model(&domain.Users{}).Where("username = ?", username).
+		UpdateColumn("active", active))
+}
+
+func (u *userRepositoryImpl) LockActiveByRole(ctx context.Context, role string) (int64, error) {
+	var usernames []string
+	err := conn(ctx, u.DB).Model(&domain.Users{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("role = ? AND active = ?", role, true).Pluck("username", &usernames).Error
+	return int64(len(usernames)), translateError(err)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"inventory-management-system/logging"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"inventory-management-system/repository"
+	"inventory-management-system/tracing"
+	"slices"
+	"time"
+)
+
+const lastUsedResolution = time.Minute
+
+type APIKeyService interface {
+	Create(ctx context.Context, apiKeyCreateRequest web.APIKeyCreateRequest, username string) (domain.APIKeyCreated, web.ErrorResponse)
+	Revoke(ctx context.Context, apiKeyID int) web.ErrorResponse
+	GetAll(ctx context.Context) ([]domain.APIKeys, web.ErrorResponse)
+	Authenticate(ctx context.Context, key string) (domain.APIKeys, web.ErrorResponse)
+}
+
+type apiKeyServiceImpl struct {
+	repository.APIKeyRepository
+}
+
+func NewAPIKeyService(apiKeyRepository repository.APIKeyRepository) APIKeyService {
+	return &apiKeyServiceImpl{apiKeyRepository}
+}
+
+func (a *apiKeyServiceImpl) Create(ctx context.Context, apiKeyCreateRequest web.APIKeyCreateRequest, username string) (domain.APIKeyCreated, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.Create")
+	defer span.End()
+
+	for _, scope := range apiKeyCreateRequest.Scopes {
+		if scope != "*" && !slices.Contains(domain.APIKeyScopes, scope) {
+			return domain.APIKeyCreated{}, web.NewBadRequestError(web.CodeUnknownScope, "unknown scope {0}", scope)
+		}
+	}
+
+	if apiKeyCreateRequest.ExpiresAt != nil && !apiKeyCreateRequest.ExpiresAt.After(time.Now()) {
+		return domain.APIKeyCreated{}, web.NewBadRequestError(web.CodeExpiryInPast, "expires_at must be in the future")
+	}
+
+	first, err := randomName()
+	if err != nil {
+		return domain.APIKeyCreated{}, internalError(ctx, err)
+	}
+
+	second, err := randomName()
+	if err != nil {
+		return domain.APIKeyCreated{}, internalError(ctx, err)
+	}
+
+	scopes := slices.Clone(apiKeyCreateRequest.Scopes)
+	slices.Sort(scopes)
+
+	key := domain.APIKeyPrefix + first + second
+	apiKey := domain.APIKeys{
+		Name:      apiKeyCreateRequest.Name,
+		Prefix:    key[:len(domain.APIKeyPrefix)+8],
+		KeyHash:   hashToken(key),
+		Scopes:    slices.Compact(scopes),
+		CreatedBy: username,
+		ExpiresAt: apiKeyCreateRequest.ExpiresAt,
+	}
+
+	if err := a.APIKeyRepository.Create(ctx, &apiKey); err != nil {
+		return domain.APIKeyCreated{}, internalError(ctx, err)
+	}
+
+	return domain.APIKeyCreated{APIKeys: apiKey, Key: key}, nil
+}
+
+func (a *apiKeyServiceImpl) Revoke(ctx context.Context, apiKeyID int) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "APIKeyService.Revoke")
+	defer span.End()
+
+	apiKey, err := a.APIKeyRepository.FindByID(ctx, apiKeyID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewNotFoundError(web.CodeAPIKeyNotFound, "api key id not found")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	if apiKey.RevokedAt != nil {
+		return web.NewConflictError(web.CodeAPIKeyAlreadyRevoked, "api key is already revoked")
+	}
+
+	err = a.APIKeyRepository.Revoke(ctx, apiKeyID, time.Now())
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewConflictError(web.CodeAPIKeyAlreadyRevoked, "api key is already revoked")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	return nil
+}
+
+func (a *apiKeyServiceImpl) GetAll(ctx context.Context) ([]domain.APIKeys, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.GetAll")
+	defer span.End()
+
+	apiKeys, err := a.APIKeyRepository.FindAll(ctx)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	if len(apiKeys) == 0 {
+		return nil, web.NewNotFoundError(web.CodeAPIKeyNotFound, "api key not found")
+	}
+
+	return apiKeys, nil
+}
+
+func (a *apiKeyServiceImpl) Authenticate(ctx context.Context, key string) (domain.APIKeys, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.Authenticate")
+	defer span.End()
+
+	apiKey, err := a.APIKeyRepository.FindByHash(ctx, hashToken(key))
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.APIKeys{}, web.NewUnauthorizedError(web.CodeAPIKeyInvalid, "api key is invalid")
+	}
+	if err != nil {
+		return domain.APIKeys{}, internalError(ctx, err)
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt != nil {
+		return domain.APIKeys{}, web.NewUnauthorizedError(web.CodeAPIKeyRevoked, "api key is revoked")
+	}
+
+	if apiKey.ExpiresAt != nil && !now.Before(*apiKey.ExpiresAt) {
+		return domain.APIKeys{}, web.NewUnauthorizedError(web.CodeAPIKeyExpired, "api key is expired")
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedResolution {
+		if err := a.APIKeyRepository.TouchLastUsed(ctx, apiKey.ID, now); err != nil {
+			logging.FromContext(ctx).WarnContext(ctx, "failed to record api key usage", "api_key_id", apiKey.ID, "error", err)
+		}
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"inventory-management-system/repository"
+	"inventory-management-system/tracing"
+	"time"
+)
+
+var (
+	errCheckedIn    = errors.New("assignment already checked in")
+	errUnitNotReady = errors.New("unit is not in stock")
+)
+
+type AssignmentService interface {
+	CheckOut(ctx context.Context, checkOutRequest web.AssignmentCheckOutRequest, username string) (domain.Assignments, web.ErrorResponse)
+	CheckIn(ctx context.Context, checkInRequest web.AssignmentCheckInRequest, username string) web.ErrorResponse
+	GetAll(ctx context.Context) ([]domain.Assignments, web.ErrorResponse)
+	GetOverdue(ctx context.Context) ([]domain.Assignments, web.ErrorResponse)
+	GetByAssignee(ctx context.Context, assignee string) ([]domain.Assignments, web.ErrorResponse)
+}
+
+type assignmentServiceImpl struct {
+	repository.Transactor
+	repository.AssignmentRepository
+	repository.ItemRepository
+	repository.ItemUnitRepository
+	repository.UserRepository
+	repository.ActivityRepository
+}
+
+func NewAssignmentService(transactor repository.Transactor, assignmentRepository repository.AssignmentRepository, itemRepository repository.ItemRepository, itemUnitRepository repository.ItemUnitRepository, userRepository repository.UserRepository, activityRepository repository.ActivityRepository) AssignmentService {
+	return &assignmentServiceImpl{transactor, assignmentRepository, itemRepository, itemUnitRepository, userRepository, activityRepository}
+}
+
+func (a *assignmentServiceImpl) CheckOut(ctx context.Context, checkOutRequest web.AssignmentCheckOutRequest, username string) (domain.Assignments, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "AssignmentService.CheckOut")
+	defer span.End()
+
+	now := time.Now()
+	if !checkOutRequest.DueDate.After(now) {
+		return domain.Assignments{}, web.NewBadRequestError(web.CodeDueDateInPast, "due date must be in the future")
+	}
+
+	_, err := a.UserRepository.FindByUsername(ctx, checkOutRequest.Assignee)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Assignments{}, web.NewNotFoundError(web.CodeUserNotFound, "assignee not found")
+	}
+	if err != nil {
+		return domain.Assignments{}, internalError(ctx, err)
+	}
+
+	item, err := a.ItemRepository.FindByID(ctx, checkOutRequest.ItemID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Assignments{}, web.NewNotFoundError(web.CodeItemNotFound, "item id not found")
+	}
+	if err != nil {
+		return domain.Assignments{}, internalError(ctx, err)
+	}
+
+	assignment := domain.Assignments{
+		ItemID:       item.ID,
+		Assignee:     checkOutRequest.Assignee,
+		Quantity:     checkOutRequest.Quantity,
+		DueDate:      checkOutRequest.DueDate,
+		Note:         checkOutRequest.Note,
+		CheckedOutBy: username,
+		CheckedOutAt: now,
+	}
+	note := fmt.Sprintf("checked out to %s, due %s", checkOutRequest.Assignee, checkOutRequest.DueDate.Format(time.DateOnly))
+
+	var unit domain.ItemUnits
+	if item.Serialized {
+		if checkOutRequest.UnitID == 0 {
+			return domain.Assignments{}, web.NewBadRequestError(web.CodeUnitRequired, "unit id is required for serialized item")
+		}
+
+		unit, err = a.ItemUnitRepository.FindByID(ctx, checkOutRequest.UnitID)
+		if errors.Is(err, repository.ErrNotFound) || (err == nil && unit.ItemID != item.ID) {
+			return domain.Assignments{}, web.NewNotFoundError(web.CodeUnitNotFound, "unit id not found")
+		}
+		if err != nil {
+			return domain.Assignments{}, internalError(ctx, err)
+		}
+
+		if unit.Status != domain.UnitStatusInStock {
+			return domain.Assignments{}, web.NewConflictError(web.CodeUnitNotInStock, "unit is not in stock")
+		}
+
+		assignment.UnitID = &unit.ID
+		assignment.Quantity = 1
+		note = "unit " + unit.SerialNumber + " " + note
+	} else {
+		if checkOutRequest.UnitID != 0 {
+			return domain.Assignments{}, web.NewBadRequestError(web.CodeItemNotSerialized, "item is not serialized")
+		}
+
+		if checkOutRequest.Quantity < 1 {
+			return domain.Assignments{}, web.NewBadRequestError(web.CodeInvalidQuantity, "quantity must be at least 1")
+		}
+
+		if item.Quantity < checkOutRequest.Quantity {
+			return domain.Assignments{}, web.NewConflictError(web.CodeInsufficientStock, "insufficient stock")
+		}
+	}
+
+	err = a.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if assignment.UnitID != nil {
+			err := a.ItemUnitRepository.Transition(ctx, &domain.ItemUnits{ID: unit.ID, Status: domain.UnitStatusAssigned}, domain.UnitStatusInStock)
+			if errors.Is(err, repository.ErrNotFound) {
+				return errUnitNotReady
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := a.ItemRepository.SyncUnitStock(ctx, item.ID); err != nil {
+				return err
+			}
+		} else if err := a.ItemRepository.AdjustQuantity(ctx, item.ID, -assignment.Quantity); err != nil {
+			return err
+		}
+
+		if err := a.AssignmentRepository.Create(ctx, &assignment); err != nil {
+			return err
+		}
+
+		return a.ActivityRepository.Create(ctx, &domain.Activities{
+			ItemID:         item.ID,
+			UnitID:         assignment.UnitID,
+			Action:         domain.ActionCheckOut,
+			QuantityChange: -assignment.Quantity,
+			Timestamp:      now,
+			PerformedBy:    username,
+			Note:           note,
+		})
+	})
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		return domain.Assignments{}, web.NewConflictError(web.CodeInsufficientStock, "insufficient stock")
+	}
+	if errors.Is(err, errUnitNotReady) {
+		return domain.Assignments{}, web.NewConflictError(web.CodeUnitNotInStock, "unit is not in stock")
+	}
+	if err != nil {
+		return domain.Assignments{}, internalError(ctx, err)
+	}
+
+	return assignment, nil
+}
+
+func (a *assignmentServiceImpl) CheckIn(ctx context.Context, checkInRequest web.AssignmentCheckInRequest, username string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "AssignmentService.CheckIn")
+	defer span.End()
+
+	assignment, err := a.AssignmentRepository.FindByID(ctx, checkInRequest.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewNotFoundError(web.CodeAssignmentNotFound, "assignment id not found")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	if assignment.CheckedInAt != nil {
+		return web.NewConflictError(web.CodeAssignmentAlreadyCheckedIn, "assignment is already checked in")
+	}
+
+	now := time.Now()
+	note := fmt.Sprintf("checked in from %s", assignment.Assignee)
+	if checkInRequest.Note != "" {
+		note += ": " + checkInRequest.Note
+	}
+
+	err = a.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := a.AssignmentRepository.CheckIn(ctx, assignment.ID, username, now)
+		if errors.Is(err, repository.ErrNotFound) {
+			return errCheckedIn
+		}
+		if err != nil {
+			return err
+		}
+
+		if assignment.UnitID != nil {
+			if err := a.ItemUnitRepository.Transition(ctx, &domain.ItemUnits{ID: *assignment.UnitID, Status: domain.UnitStatusInStock}, domain.UnitStatusAssigned); err != nil {
+				return err
+			}
+
+			if err := a.ItemRepository.SyncUnitStock(ctx, assignment.ItemID); err != nil {
+				return err
+			}
+		} else if err := a.ItemRepository.AdjustQuantity(ctx, assignment.ItemID, assignment.Quantity); err != nil {
+			return err
+		}
+
+		return a.ActivityRepository.Create(ctx, &domain.Activities{
+			ItemID:         assignment.ItemID,
+			UnitID:         assignment.UnitID,
+			Action:         domain.ActionCheckIn,
+			QuantityChange: assignment.Quantity,
+			Timestamp:      now,
+			PerformedBy:    username,
+			Note:           note,
+		})
+	})
+	if errors.Is(err, errCheckedIn) {
+		return web.NewConflictError(web.CodeAssignmentAlreadyCheckedIn, "assignment is already checked in")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	return nil
+}
+
+func (a *assignmentServiceImpl) GetAll(ctx context.Context) ([]domain.Assignments, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "AssignmentService.GetAll")
+	defer span.End()
+
+	assignments, err := a.AssignmentRepository.FindOpen(ctx)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	if len(assignments) == 0 {
+		return nil, web.NewNotFoundError(web.CodeAssignmentNotFound, "assignment not found")
+	}
+
+	return assignments, nil
+}
+
+func (a *assignmentServiceImpl) GetOverdue(ctx context.Context) ([]domain.Assignments, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "AssignmentService.GetOverdue")
+	defer span.End()
+
+	assignments, err := a.AssignmentRepository.FindOverdue(ctx, time.Now())
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	if len(assignments) == 0 {
+		return nil, web.NewNotFoundError(web.CodeAssignmentNotFound, "overdue assignment not found")
+	}
+
+	return assignments, nil
+}
+
+func (a *assignmentServiceImpl) GetByAssignee(ctx context.Context, assignee string) ([]domain.Assignments, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "AssignmentService.GetByAssignee")
+	defer span.End()
+
+	assignments, err := a.AssignmentRepository.FindOpenByAssignee(ctx, assignee)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	if len(assignments) == 0 {
+		return nil, web.NewNotFoundError(web.CodeAssignmentNotFound, "assignment not found")
+	}
+
+	return assignments, nil
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"inventory-management-system/helper"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"inventory-management-system/repository"
+	"inventory-management-system/storage"
+	"inventory-management-system/tracing"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var allowedAttachmentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+	"text/plain":      ".txt",
+}
+
+type AttachmentService interface {
+	Upload(ctx context.Context, attachmentUploadRequest web.AttachmentUploadRequest, content io.Reader, username string) (domain.Attachments, web.ErrorResponse)
+	Delete(ctx context.Context, attachmentID int, username string) web.ErrorResponse
+	GetByID(ctx context.Context, attachmentID int) (domain.Attachments, web.ErrorResponse)
+	GetByItemID(ctx context.Context, itemID int) ([]domain.Attachments, web.ErrorResponse)
+	Open(ctx context.Context, attachment domain.Attachments, thumbnail bool) (io.ReadCloser, web.ErrorResponse)
+}
+
+type attachmentServiceImpl struct {
+	repository.Transactor
+	repository.AttachmentRepository
+	repository.ItemRepository
+	repository.ActivityRepository
+	storage.Storage
+	maxUploadSize int64
+}
+
+func NewAttachmentService(transactor repository.Transactor, attachmentRepository repository.AttachmentRepository, itemRepository repository.ItemRepository, activityRepository repository.ActivityRepository, fileStorage storage.Storage, maxUploadSize int64) AttachmentService {
+	return &attachmentServiceImpl{transactor, attachmentRepository, itemRepository, activityRepository, fileStorage, maxUploadSize}
+}
+
+func (a *attachmentServiceImpl) Upload(ctx context.Context, attachmentUploadRequest web.AttachmentUploadRequest, content io.Reader, username string) (domain.Attachments, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.Upload")
+	defer span.End()
+
+	if attachmentUploadRequest.Size > a.maxUploadSize {
+		return domain.Attachments{}, web.NewRequestEntityTooLargeError(web.CodeFileTooLarge, "file exceeds the {0} bytes limit", strconv.FormatInt(a.maxUploadSize, 10))
+	}
+
+	item, err := a.ItemRepository.FindByID(ctx, attachmentUploadRequest.ItemID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Attachments{}, web.NewNotFoundError(web.CodeItemNotFound, "item id not found")
+	}
+	if err != nil {
+		return domain.Attachments{}, internalError(ctx, err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, a.maxUploadSize+1))
+	if err != nil {
+		return domain.Attachments{}, web.NewBadRequestError(web.CodeFileUnreadable, "failed to read file")
+	}
+
+	if int64(len(data)) > a.maxUploadSize {
+		return domain.Attachments{}, web.NewRequestEntityTooLargeError(web.CodeFileTooLarge, "file exceeds the {0} bytes limit", strconv.FormatInt(a.maxUploadSize, 10))
+	}
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil {
+		return domain.Attachments{}, web.NewUnsupportedMediaTypeError(web.CodeFileTypeNotAllowed, "unknown file type")
+	}
+
+	extension, ok := allowedAttachmentTypes[contentType]
+	if !ok {
+		return domain.Attachments{}, web.NewUnsupportedMediaTypeError(web.CodeFileTypeNotAllowed, "file type {0} is not allowed", contentType)
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return domain.Attachments{}, internalError(ctx, err)
+	}
+
+	attachment := domain.Attachments{
+		ItemID:      item.ID,
+		Kind:        attachmentUploadRequest.Kind,
+		FileName:    filepath.Base(attachmentUploadRequest.FileName),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		StorageKey:  fmt.Sprintf("items/%d/%s%s", item.ID, name, extension),
+		UploadedBy:  username,
+	}
+
+	err = a.Storage.Put(ctx, attachment.StorageKey, bytes.NewReader(data), attachment.Size, contentType)
+	if err != nil {
+		return domain.Attachments{}, internalError(ctx, err)
+	}
+
+	if strings.HasPrefix(contentType, "image/") {
+		thumbnail, err := helper.MakeThumbnail(data)
+		if err == nil {
+			thumbnailKey := fmt.Sprintf("items/%d/thumbnails/%s.jpg", item.ID, name)
+			if err := a.Storage.Put(ctx, thumbnailKey, bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/jpeg"); err == nil {
+				attachment.ThumbnailKey = thumbnailKey
+				attachment.HasThumbnail = true
+			}
+		}
+	}
+
+	err = a.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := a.AttachmentRepository.Create(ctx, &attachment); err != nil {
+			return err
+		}
+
+		return a.ActivityRepository.Create(ctx, &domain.Activities{
+			ItemID:      item.ID,
+			Action:      domain.ActionAttach,
+			Timestamp:   time.Now(),
+			PerformedBy: username,
+			Note:        fmt.Sprintf("%s %s attached", attachment.Kind, attachment.FileName),
+		})
+	})
+	if err != nil {
+		a.removeFiles(context.WithoutCancel(ctx), attachment)
+		return domain.Attachments{}, internalError(ctx, err)
+	}
+
+	return attachment, nil
+}
+
+func (a *attachmentServiceImpl) Delete(ctx context.Context, attachmentID int, username string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "AttachmentService.Delete")
+	defer span.End()
+
+	attachment, err := a.AttachmentRepository.FindByID(ctx, attachmentID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewNotFoundError(web.CodeAttachmentNotFound, "attachment id not found")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	err = a.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := a.AttachmentRepository.Delete(ctx, attachment.ID); err != nil {
+			return err
+		}
+
+		return a.ActivityRepository.Create(ctx, &domain.Activities{
+			ItemID:      attachment.ItemID,
+			Action:      domain.ActionDetach,
+			Timestamp:   time.Now(),
+			PerformedBy: username,
+			Note:        fmt.Sprintf("%s %s detached", attachment.Kind, attachment.FileName),
+		})
+	})
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	a.removeFiles(context.WithoutCancel(ctx), attachment)
+	return nil
+}
+
+func (a *attachmentServiceImpl) GetByID(ctx context.Context, attachmentID int) (domain.Attachments, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.GetByID")
+	defer span.End()
+
+	attachment, err := a.AttachmentRepository.FindByID(ctx, attachmentID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Attachments{}, web.NewNotFoundError(web.CodeAttachmentNotFound, "attachment not found")
+	}
+	if err != nil {
+		return domain.Attachments{}, internalError(ctx, err)
+	}
+
+	return attachment, nil
+}
+
+func (a *attachmentServiceImpl) GetByItemID(ctx context.Context, itemID int) ([]domain.Attachments, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.GetByItemID")
+	defer span.End()
+
+	attachments, err := a.AttachmentRepository.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	if len(attachments) == 0 {
+		return nil, web.NewNotFoundError(web.CodeAttachmentNotFound, "attachment not found")
+	}
+
+	return attachments, nil
+}
+
+func (a *attachmentServiceImpl) Open(ctx context.Context, attachment domain.Attachments, thumbnail bool) (io.ReadCloser, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.Open")
+	defer span.End()
+
+	key := attachment.StorageKey
+	if thumbnail {
+		if !attachment.HasThumbnail {
+			return nil, web.NewNotFoundError(web.CodeThumbnailNotFound, "attachment has no thumbnail")
+		}
+		key = attachment.ThumbnailKey
+	}
+
+	content, err := a.Storage.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, web.NewNotFoundError(web.CodeAttachmentNotFound, "attachment file not found")
+	}
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	return content, nil
+}
+
+func (a *attachmentServiceImpl) removeFiles(ctx context.Context, attachment domain.Attachments) {
+	_ = a.Storage.Delete(ctx, attachment.StorageKey)
+	if attachment.HasThumbnail {
+		_ = a.Storage.Delete(ctx, attachment.ThumbnailKey)
+	}
+}
+
+func randomName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
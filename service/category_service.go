@@ -1,115 +1,232 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"inventory-management-system/helper"
 	"inventory-management-system/model/domain"
 	"inventory-management-system/model/web"
 	"inventory-management-system/repository"
+	"inventory-management-system/tracing"
+	"net/http"
 )
 
 type CategoryService interface {
-	Add(categoryAddRequest *web.CategoryAddRequest) web.ErrorResponse
-	Update(categoryUpdateRequest web.CategoryUpdateRequest) web.ErrorResponse
-	Delete(categoryID int) web.ErrorResponse
-	GetAll() ([]domain.Categories, web.ErrorResponse)
-	GetByID(categoryID int) (domain.Categories, web.ErrorResponse)
-	CheckAvailable(name string) bool
+	Add(ctx context.Context, categoryAddRequest *web.CategoryAddRequest) web.ErrorResponse
+	Update(ctx context.Context, categoryUpdateRequest web.CategoryUpdateRequest) web.ErrorResponse
+	Delete(ctx context.Context, categoryID int) web.ErrorResponse
+	GetAll(ctx context.Context) ([]domain.Categories, web.ErrorResponse)
+	GetByID(ctx context.Context, categoryID int) (domain.Categories, web.ErrorResponse)
+	CheckAvailable(ctx context.Context, name string) bool
+	AddAttribute(ctx context.Context, categoryAttributeAddRequest web.CategoryAttributeAddRequest) (domain.CategoryAttributes, web.ErrorResponse)
+	DeleteAttribute(ctx context.Context, categoryID int, attributeID int) web.ErrorResponse
+	GetAttributes(ctx context.Context, categoryID int) ([]domain.CategoryAttributes, web.ErrorResponse)
 }
 
 type categoryServiceImpl struct {
-	repository.HandlerRepository
+	repository.CategoryRepository
 }
 
-func NewCategoryService(handleRepository repository.HandlerRepository) CategoryService {
-	return &categoryServiceImpl{handleRepository}
+func NewCategoryService(categoryRepository repository.CategoryRepository) CategoryService {
+	return &categoryServiceImpl{categoryRepository}
 }
 
-func (c *categoryServiceImpl) Add(categoryAddRequest *web.CategoryAddRequest) web.ErrorResponse {
-	result := c.CheckAvailable(categoryAddRequest.Name)
+func (c *categoryServiceImpl) Add(ctx context.Context, categoryAddRequest *web.CategoryAddRequest) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "CategoryService.Add")
+	defer span.End()
+
+	result := c.CheckAvailable(ctx, categoryAddRequest.Name)
 	if result {
-		return web.NewBadRequestError("category already exists")
+		return web.NewConflictError(web.CodeCategoryNameTaken, "category already exists")
 	}
 
-	err := c.HandlerRepository.Add(&domain.Categories{
+	err := c.CategoryRepository.Create(ctx, &domain.Categories{
 		Name: categoryAddRequest.Name,
 	})
 	if err != nil {
-		return web.NewInternalServerErrorError(err.Error())
+		return internalError(ctx, err)
 	}
 
 	return nil
 }
 
-func (c *categoryServiceImpl) Update(categoryUpdateRequest web.CategoryUpdateRequest) web.ErrorResponse {
-	ok := c.CheckAvailable(categoryUpdateRequest.Name)
+func (c *categoryServiceImpl) Update(ctx context.Context, categoryUpdateRequest web.CategoryUpdateRequest) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "CategoryService.Update")
+	defer span.End()
+
+	ok := c.CheckAvailable(ctx, categoryUpdateRequest.Name)
 	if ok {
-		return web.NewBadRequestError("category already exists")
+		return web.NewConflictError(web.CodeCategoryNameTaken, "category already exists")
 	}
 
-	category := domain.Categories{}
-	err := c.HandlerRepository.GetByID(categoryUpdateRequest.ID, &category)
+	category, err := c.CategoryRepository.FindByID(ctx, categoryUpdateRequest.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewBadRequestError(web.CodeCategoryNotFound, "category id not exists")
+	}
 	if err != nil {
-		return web.NewBadRequestError("category id not exists")
+		return internalError(ctx, err)
 	}
 
-	result := c.CheckAvailable(category.Name)
+	result := c.CheckAvailable(ctx, category.Name)
 	if !result {
-		return web.NewBadRequestError("category name exists")
+		return web.NewConflictError(web.CodeCategoryNameTaken, "category name exists")
 	}
 
-	err = c.HandlerRepository.UpdateByID(categoryUpdateRequest.ID, &domain.Categories{
+	err = c.CategoryRepository.Update(ctx, &domain.Categories{
 		ID:   categoryUpdateRequest.ID,
 		Name: categoryUpdateRequest.Name,
 	})
 	if err != nil {
-		return web.NewInternalServerErrorError(err.Error())
+		return internalError(ctx, err)
 	}
 
 	return nil
 }
 
-func (c *categoryServiceImpl) Delete(categoryID int) web.ErrorResponse {
-	category := domain.Categories{}
-	err := c.HandlerRepository.GetByID(categoryID, &category)
+func (c *categoryServiceImpl) Delete(ctx context.Context, categoryID int) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "CategoryService.Delete")
+	defer span.End()
+
+	_, err := c.CategoryRepository.FindByID(ctx, categoryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewBadRequestError(web.CodeCategoryNotFound, "category id not exists")
+	}
 	if err != nil {
-		return web.NewBadRequestError("category id not exists")
+		return internalError(ctx, err)
 	}
 
-	err = c.HandlerRepository.DeleteByID(categoryID, &domain.Categories{})
+	err = c.CategoryRepository.Delete(ctx, categoryID)
 	if err != nil {
-		return web.NewInternalServerErrorError(err.Error())
+		return internalError(ctx, err)
 	}
 
 	return nil
 }
 
-func (c *categoryServiceImpl) GetAll() ([]domain.Categories, web.ErrorResponse) {
-	categories := []domain.Categories{}
-	err := c.HandlerRepository.GetAll(&categories)
+func (c *categoryServiceImpl) GetAll(ctx context.Context) ([]domain.Categories, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "CategoryService.GetAll")
+	defer span.End()
+
+	categories, err := c.CategoryRepository.FindAll(ctx)
 	if err != nil {
-		return nil, web.NewInternalServerErrorError(err.Error())
+		return nil, internalError(ctx, err)
 	}
 
 	if len(categories) == 0 {
-		return nil, web.NewNotFoundError("category not found")
+		return nil, web.NewNotFoundError(web.CodeCategoryNotFound, "category not found")
 	}
 
 	return categories, nil
 }
 
-func (c *categoryServiceImpl) GetByID(categoryID int) (domain.Categories, web.ErrorResponse) {
-	category := domain.Categories{}
-	err := c.HandlerRepository.GetByID(categoryID, &category)
+func (c *categoryServiceImpl) GetByID(ctx context.Context, categoryID int) (domain.Categories, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "CategoryService.GetByID")
+	defer span.End()
+
+	category, err := c.CategoryRepository.FindByID(ctx, categoryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Categories{}, web.NewNotFoundError(web.CodeCategoryNotFound, "category not found")
+	}
 	if err != nil {
-		return domain.Categories{}, web.NewInternalServerErrorError(err.Error())
+		return domain.Categories{}, internalError(ctx, err)
 	}
 
 	return category, nil
 }
 
-func (c *categoryServiceImpl) CheckAvailable(name string) bool {
-	err := c.HandlerRepository.GetByName(name, &domain.Categories{})
+func (c *categoryServiceImpl) CheckAvailable(ctx context.Context, name string) bool {
+	ctx, span := tracing.Start(ctx, "CategoryService.CheckAvailable")
+	defer span.End()
+
+	_, err := c.CategoryRepository.FindByName(ctx, name)
 	if err != nil {
 		return false
 	}
 	return true
 }
+
+func (c *categoryServiceImpl) AddAttribute(ctx context.Context, categoryAttributeAddRequest web.CategoryAttributeAddRequest) (domain.CategoryAttributes, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "CategoryService.AddAttribute")
+	defer span.End()
+
+	category, err := c.CategoryRepository.FindByID(ctx, categoryAttributeAddRequest.CategoryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.CategoryAttributes{}, web.NewNotFoundError(web.CodeCategoryNotFound, "category id not found")
+	}
+	if err != nil {
+		return domain.CategoryAttributes{}, internalError(ctx, err)
+	}
+
+	if !helper.IsValidAttributeName(categoryAttributeAddRequest.Name) {
+		return domain.CategoryAttributes{}, web.NewBadRequestError(web.CodeAttributeNameInvalid, "attribute name must be lowercase letters, digits and underscores")
+	}
+
+	attributes, errResponse := c.GetAttributes(ctx, category.ID)
+	if errResponse != nil && errResponse.Code() != http.StatusNotFound {
+		return domain.CategoryAttributes{}, errResponse
+	}
+
+	for _, attribute := range attributes {
+		if attribute.Name == categoryAttributeAddRequest.Name {
+			return domain.CategoryAttributes{}, web.NewConflictError(web.CodeAttributeNameTaken, "attribute name is already in use")
+		}
+	}
+
+	attribute := domain.CategoryAttributes{
+		CategoryID: category.ID,
+		Name:       categoryAttributeAddRequest.Name,
+		Label:      categoryAttributeAddRequest.Label,
+		Type:       categoryAttributeAddRequest.Type,
+		Unit:       categoryAttributeAddRequest.Unit,
+		Required:   categoryAttributeAddRequest.Required,
+	}
+	if attribute.Type == domain.AttributeTypeEnum {
+		attribute.Options = categoryAttributeAddRequest.Options
+	}
+
+	err = c.CategoryRepository.CreateAttribute(ctx, &attribute)
+	if errors.Is(err, repository.ErrDuplicate) {
+		return domain.CategoryAttributes{}, web.NewConflictError(web.CodeAttributeNameTaken, "attribute name is already in use")
+	}
+	if err != nil {
+		return domain.CategoryAttributes{}, internalError(ctx, err)
+	}
+
+	return attribute, nil
+}
+
+func (c *categoryServiceImpl) DeleteAttribute(ctx context.Context, categoryID int, attributeID int) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "CategoryService.DeleteAttribute")
+	defer span.End()
+
+	attribute, err := c.CategoryRepository.FindAttributeByID(ctx, attributeID)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && attribute.CategoryID != categoryID) {
+		return web.NewNotFoundError(web.CodeAttributeNotFound, "attribute id not found")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	err = c.CategoryRepository.DeleteAttribute(ctx, attribute.ID)
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	return nil
+}
+
+func (c *categoryServiceImpl) GetAttributes(ctx context.Context, categoryID int) ([]domain.CategoryAttributes, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "CategoryService.GetAttributes")
+	defer span.End()
+
+	attributes, err := c.CategoryRepository.FindAttributes(ctx, categoryID)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	if len(attributes) == 0 {
+		return nil, web.NewNotFoundError(web.CodeAttributeNotFound, "attribute not found")
+	}
+
+	return attributes, nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel/trace"
+	"inventory-management-system/logging"
+	"inventory-management-system/model/web"
+	"inventory-management-system/password"
+	"inventory-management-system/tracing"
+)
+
+func internalError(ctx context.Context, err error) web.ErrorResponse {
+	tracing.RecordError(trace.SpanFromContext(ctx), err)
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		logging.FromContext(ctx).WarnContext(ctx, "request deadline exceeded", "error", err)
+		return web.NewGatewayTimeoutError()
+	}
+
+	logging.FromContext(ctx).ErrorContext(ctx, "internal error", "error", err)
+	return web.NewInternalServerErrorError()
+}
+
+func weakPasswordError(err error) web.ErrorResponse {
+	var policyErr *password.Error
+	if errors.As(err, &policyErr) {
+		return web.NewBadRequestError(web.CodeWeakPassword, policyErr.Message, policyErr.Params...)
+	}
+	return web.NewBadRequestError(web.CodeWeakPassword, err.Error())
+}
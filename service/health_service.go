@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"inventory-management-system/logging"
+	"inventory-management-system/model/web"
+	"inventory-management-system/repository"
+	"inventory-management-system/tracing"
+)
+
+type HealthService interface {
+	Ready(ctx context.Context) web.ErrorResponse
+}
+
+type healthServiceImpl struct {
+	repository.HealthRepository
+}
+
+func NewHealthService(healthRepository repository.HealthRepository) HealthService {
+	return &healthServiceImpl{healthRepository}
+}
+
+func (h *healthServiceImpl) Ready(ctx context.Context) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "HealthService.Ready")
+	defer span.End()
+
+	if err := h.HealthRepository.Ping(ctx); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "database is unreachable", "error", err)
+		return web.NewServiceUnavailableError(web.CodeDatabaseUnavailable, "database is unreachable")
+	}
+
+	return nil
+}
@@ -1,38 +1,53 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"inventory-management-system/model/domain"
 	"inventory-management-system/model/web"
 	"inventory-management-system/repository"
+	"inventory-management-system/tracing"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type ItemService interface {
-	Add(itemAddRequest web.ItemAddRequest, username string) web.ErrorResponse
-	Update(itemUpdateRequest web.ItemUpdateRequest, username string) web.ErrorResponse
-	Delete(itemID int, username string) web.ErrorResponse
-	GetAll() ([]domain.Items, web.ErrorResponse)
-	GetByID(itemID int) (domain.Items, web.ErrorResponse)
-	CheckAvailable(name string) bool
+	Add(ctx context.Context, itemAddRequest web.ItemAddRequest, username string) web.ErrorResponse
+	Update(ctx context.Context, itemUpdateRequest web.ItemUpdateRequest, username string) web.ErrorResponse
+	Delete(ctx context.Context, itemID int, username string) web.ErrorResponse
+	GetAll(ctx context.Context, filters []domain.AttributeFilter) ([]domain.Items, web.ErrorResponse)
+	GetByID(ctx context.Context, itemID int) (domain.Items, web.ErrorResponse)
+	CheckAvailable(ctx context.Context, name string) bool
 }
 
 type itemServiceImpl struct {
-	repository.HandlerRepository
+	repository.Transactor
+	repository.ItemRepository
+	repository.CategoryRepository
+	repository.ActivityRepository
 }
 
-func NewItemService(handlerRepository repository.HandlerRepository) ItemService {
-	return &itemServiceImpl{handlerRepository}
+func NewItemService(transactor repository.Transactor, itemRepository repository.ItemRepository, categoryRepository repository.CategoryRepository, activityRepository repository.ActivityRepository) ItemService {
+	return &itemServiceImpl{transactor, itemRepository, categoryRepository, activityRepository}
 }
 
-func (i *itemServiceImpl) Add(itemAddRequest web.ItemAddRequest, username string) web.ErrorResponse {
-	category := domain.Categories{}
-	err := i.HandlerRepository.GetByID(itemAddRequest.CategoryID, &category)
+func (i *itemServiceImpl) Add(ctx context.Context, itemAddRequest web.ItemAddRequest, username string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "ItemService.Add")
+	defer span.End()
+
+	_, err := i.CategoryRepository.FindByID(ctx, itemAddRequest.CategoryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewNotFoundError(web.CodeCategoryNotFound, "category id not found")
+	}
 	if err != nil {
-		return web.NewNotFoundError("category id not found")
+		return internalError(ctx, err)
 	}
 
-	if ok := i.CheckAvailable(itemAddRequest.Name); ok {
-		return web.NewBadRequestError("item name is already in use")
+	if ok := i.CheckAvailable(ctx, itemAddRequest.Name); ok {
+		return web.NewConflictError(web.CodeItemNameTaken, "item name is already in use")
 	}
 
 	item := domain.Items{
@@ -41,44 +56,69 @@ func (i *itemServiceImpl) Add(itemAddRequest web.ItemAddRequest, username string
 		Price:         itemAddRequest.Price,
 		Quantity:      itemAddRequest.Quantity,
 		Specification: itemAddRequest.Specification,
+		Serialized:    itemAddRequest.Serialized,
 	}
-	err = i.HandlerRepository.Add(&item)
-	if err != nil {
-		return web.NewInternalServerErrorError(err.Error())
+	if item.Serialized {
+		item.Quantity = 0
 	}
 
-	err = i.HandlerRepository.Add(&domain.Activities{
-		ItemID:         item.ID,
-		Action:         "POST",
-		QuantityChange: item.Quantity,
-		Timestamp:      time.Now(),
-		PerformedBy:    username,
+	attributeValues, errResponse := i.buildAttributeValues(ctx, itemAddRequest.CategoryID, itemAddRequest.Attributes)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	err = i.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := i.ItemRepository.Create(ctx, &item); err != nil {
+			return err
+		}
+
+		if err := i.ItemRepository.ReplaceAttributeValues(ctx, item.ID, attributeValues); err != nil {
+			return err
+		}
+
+		return i.ActivityRepository.Create(ctx, &domain.Activities{
+			ItemID:         item.ID,
+			Action:         domain.ActionPost,
+			QuantityChange: item.Quantity,
+			Timestamp:      time.Now(),
+			PerformedBy:    username,
+		})
 	})
+	if errors.Is(err, repository.ErrDuplicate) {
+		return web.NewConflictError(web.CodeItemNameTaken, "item name is already in use")
+	}
 	if err != nil {
-		return web.NewInternalServerErrorError(err.Error())
+		return internalError(ctx, err)
 	}
 
 	return nil
 }
 
-func (i *itemServiceImpl) Update(itemUpdateRequest web.ItemUpdateRequest, username string) web.ErrorResponse {
-	category := domain.Categories{}
-	err := i.HandlerRepository.GetByID(itemUpdateRequest.CategoryID, &category)
+func (i *itemServiceImpl) Update(ctx context.Context, itemUpdateRequest web.ItemUpdateRequest, username string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "ItemService.Update")
+	defer span.End()
+
+	_, err := i.CategoryRepository.FindByID(ctx, itemUpdateRequest.CategoryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewNotFoundError(web.CodeCategoryNotFound, "category id not found")
+	}
 	if err != nil {
-		return web.NewNotFoundError("category id not found")
+		return internalError(ctx, err)
 	}
 
-	itemDB := domain.Items{}
-	err = i.HandlerRepository.GetByID(itemUpdateRequest.ID, &itemDB)
+	itemDB, err := i.ItemRepository.FindByID(ctx, itemUpdateRequest.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewNotFoundError(web.CodeItemNotFound, "item id not found")
+	}
 	if err != nil {
-		return web.NewNotFoundError("item id not found")
+		return internalError(ctx, err)
 	}
 
 	if itemDB.ID == itemUpdateRequest.ID && itemDB.Name == itemUpdateRequest.Name {
 
 	} else {
-		if ok := i.CheckAvailable(itemUpdateRequest.Name); ok {
-			return web.NewBadRequestError("item name is already in use")
+		if ok := i.CheckAvailable(ctx, itemUpdateRequest.Name); ok {
+			return web.NewConflictError(web.CodeItemNameTaken, "item name is already in use")
 		}
 	}
 
@@ -90,85 +130,265 @@ func (i *itemServiceImpl) Update(itemUpdateRequest web.ItemUpdateRequest, userna
 		Quantity:      itemUpdateRequest.Quantity,
 		Specification: itemUpdateRequest.Specification,
 	}
-	err = i.HandlerRepository.UpdateByID(itemUpdateRequest.ID, &item)
-	if err != nil {
-		return web.NewInternalServerErrorError(err.Error())
+	if itemDB.Serialized {
+		item.Quantity = itemDB.Quantity
+	}
+
+	attributeValues, errResponse := i.buildAttributeValues(ctx, itemUpdateRequest.CategoryID, itemUpdateRequest.Attributes)
+	if errResponse != nil {
+		return errResponse
 	}
 
 	var quantityChange int
-	if itemDB.Quantity == itemUpdateRequest.Quantity {
+	if itemDB.Quantity == item.Quantity {
 		quantityChange = 0
 	} else {
-		quantityChange = itemUpdateRequest.Quantity - itemDB.Quantity
+		quantityChange = item.Quantity - itemDB.Quantity
 	}
 
-	err = i.HandlerRepository.Add(&domain.Activities{
-		ItemID:         item.ID,
-		Action:         "UPDATE",
-		QuantityChange: quantityChange,
-		Timestamp:      time.Now(),
-		PerformedBy:    username,
+	err = i.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := i.ItemRepository.Update(ctx, &item); err != nil {
+			return err
+		}
+
+		if err := i.ItemRepository.ReplaceAttributeValues(ctx, item.ID, attributeValues); err != nil {
+			return err
+		}
+
+		return i.ActivityRepository.Create(ctx, &domain.Activities{
+			ItemID:         item.ID,
+			Action:         domain.ActionUpdate,
+			QuantityChange: quantityChange,
+			Timestamp:      time.Now(),
+			PerformedBy:    username,
+		})
 	})
+	if errors.Is(err, repository.ErrDuplicate) {
+		return web.NewConflictError(web.CodeItemNameTaken, "item name is already in use")
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewNotFoundError(web.CodeItemNotFound, "item id not found")
+	}
 	if err != nil {
-		return web.NewInternalServerErrorError(err.Error())
+		return internalError(ctx, err)
 	}
 
 	return nil
 }
 
-func (i *itemServiceImpl) Delete(itemID int, username string) web.ErrorResponse {
-	item := domain.Items{}
-	err := i.HandlerRepository.GetByID(itemID, &item)
+func (i *itemServiceImpl) Delete(ctx context.Context, itemID int, username string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "ItemService.Delete")
+	defer span.End()
+
+	_, err := i.ItemRepository.FindByID(ctx, itemID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewNotFoundError(web.CodeItemNotFound, "item id not found")
+	}
 	if err != nil {
-		return web.NewBadRequestError("item id not found")
+		return internalError(ctx, err)
 	}
 
-	if err := i.HandlerRepository.DeleteByID(itemID, domain.Items{}); err != nil {
-		return web.NewInternalServerErrorError(err.Error())
-	}
+	err = i.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := i.ItemRepository.Delete(ctx, itemID); err != nil {
+			return err
+		}
+
+		if err := i.ItemRepository.ReplaceAttributeValues(ctx, itemID, nil); err != nil {
+			return err
+		}
 
-	err = i.HandlerRepository.Add(&domain.Activities{
-		ItemID:         itemID,
-		Action:         "DELETE",
-		QuantityChange: 0,
-		Timestamp:      time.Now(),
-		PerformedBy:    username,
+		return i.ActivityRepository.Create(ctx, &domain.Activities{
+			ItemID:         itemID,
+			Action:         domain.ActionDelete,
+			QuantityChange: 0,
+			Timestamp:      time.Now(),
+			PerformedBy:    username,
+		})
 	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewNotFoundError(web.CodeItemNotFound, "item id not found")
+	}
 	if err != nil {
-		return web.NewInternalServerErrorError(err.Error())
+		return internalError(ctx, err)
 	}
 
 	return nil
 }
 
-func (i *itemServiceImpl) GetAll() ([]domain.Items, web.ErrorResponse) {
-	items := []domain.Items{}
-	err := i.HandlerRepository.GetAll(&items)
+func (i *itemServiceImpl) GetAll(ctx context.Context, filters []domain.AttributeFilter) ([]domain.Items, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "ItemService.GetAll")
+	defer span.End()
+
+	var items []domain.Items
+	var err error
+	if len(filters) == 0 {
+		items, err = i.ItemRepository.FindAll(ctx)
+	} else {
+		items, err = i.ItemRepository.FindByAttributes(ctx, filters)
+	}
 	if err != nil {
-		return nil, web.NewInternalServerErrorError(err.Error())
+		return nil, internalError(ctx, err)
 	}
 
 	if len(items) == 0 {
-		return items, web.NewNotFoundError("item not found")
+		return items, web.NewNotFoundError(web.CodeItemNotFound, "item not found")
+	}
+
+	if err := i.loadAttributes(ctx, items); err != nil {
+		return nil, internalError(ctx, err)
 	}
 
 	return items, nil
 }
 
-func (i *itemServiceImpl) GetByID(itemID int) (domain.Items, web.ErrorResponse) {
-	item := domain.Items{}
-	err := i.HandlerRepository.GetByID(itemID, &item)
+func (i *itemServiceImpl) GetByID(ctx context.Context, itemID int) (domain.Items, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "ItemService.GetByID")
+	defer span.End()
+
+	item, err := i.ItemRepository.FindByID(ctx, itemID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Items{}, web.NewNotFoundError(web.CodeItemNotFound, "item not found")
+	}
 	if err != nil {
-		return domain.Items{}, web.NewInternalServerErrorError(err.Error())
+		return domain.Items{}, internalError(ctx, err)
 	}
 
-	return item, nil
+	items := []domain.Items{item}
+	if err := i.loadAttributes(ctx, items); err != nil {
+		return domain.Items{}, internalError(ctx, err)
+	}
+
+	return items[0], nil
 }
 
-func (i *itemServiceImpl) CheckAvailable(name string) bool {
-	err := i.HandlerRepository.GetByName(name, &domain.Items{})
+func (i *itemServiceImpl) CheckAvailable(ctx context.Context, name string) bool {
+	ctx, span := tracing.Start(ctx, "ItemService.CheckAvailable")
+	defer span.End()
+
+	_, err := i.ItemRepository.FindByName(ctx, name)
 	if err != nil {
 		return false
 	}
 	return true
 }
+
+func (i *itemServiceImpl) buildAttributeValues(ctx context.Context, categoryID int, attributes map[string]any) ([]domain.ItemAttributeValues, web.ErrorResponse) {
+	schema, err := i.CategoryRepository.FindAttributes(ctx, categoryID)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	known := make(map[string]bool, len(schema))
+	for _, attribute := range schema {
+		known[attribute.Name] = true
+	}
+
+	for name := range attributes {
+		if !known[name] {
+			return nil, web.NewBadRequestError(web.CodeAttributeUnknown, "unknown attribute {0}", name)
+		}
+	}
+
+	values := make([]domain.ItemAttributeValues, 0, len(attributes))
+	for _, attribute := range schema {
+		raw, ok := attributes[attribute.Name]
+		if !ok || raw == nil {
+			if attribute.Required {
+				return nil, web.NewBadRequestError(web.CodeAttributeRequired, "attribute {0} is required", attribute.Name)
+			}
+			continue
+		}
+
+		value, errResponse := attributeValue(attribute, raw)
+		if errResponse != nil {
+			return nil, errResponse
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func (i *itemServiceImpl) loadAttributes(ctx context.Context, items []domain.Items) error {
+	itemIDs := make([]int, len(items))
+	for index, item := range items {
+		itemIDs[index] = item.ID
+	}
+
+	values, err := i.ItemRepository.FindAttributeValues(ctx, itemIDs)
+	if err != nil {
+		return err
+	}
+
+	attributes := make(map[int]map[string]any)
+	for _, value := range values {
+		if attributes[value.ItemID] == nil {
+			attributes[value.ItemID] = make(map[string]any)
+		}
+
+		switch value.Type {
+		case domain.AttributeTypeInteger, domain.AttributeTypeNumber:
+			attributes[value.ItemID][value.Name] = *value.NumberValue
+		case domain.AttributeTypeBoolean:
+			attributes[value.ItemID][value.Name] = value.Value == "true"
+		default:
+			attributes[value.ItemID][value.Name] = value.Value
+		}
+	}
+
+	for index := range items {
+		items[index].Attributes = attributes[items[index].ID]
+	}
+
+	return nil
+}
+
+func attributeValue(attribute domain.CategoryAttributes, raw any) (domain.ItemAttributeValues, web.ErrorResponse) {
+	value := domain.ItemAttributeValues{
+		AttributeID: attribute.ID,
+		Name:        attribute.Name,
+		Type:        attribute.Type,
+	}
+
+	switch attribute.Type {
+	case domain.AttributeTypeInteger, domain.AttributeTypeNumber:
+		number, ok := raw.(float64)
+		if !ok {
+			return value, web.NewBadRequestError(web.CodeAttributeValueInvalid, "attribute {0} must be a number", attribute.Name)
+		}
+
+		if attribute.Type == domain.AttributeTypeInteger && number != math.Trunc(number) {
+			return value, web.NewBadRequestError(web.CodeAttributeValueInvalid, "attribute {0} must be an integer", attribute.Name)
+		}
+
+		value.Value = strconv.FormatFloat(number, 'f', -1, 64)
+		value.NumberValue = &number
+	case domain.AttributeTypeBoolean:
+		boolean, ok := raw.(bool)
+		if !ok {
+			return value, web.NewBadRequestError(web.CodeAttributeValueInvalid, "attribute {0} must be a boolean", attribute.Name)
+		}
+
+		value.Value = strconv.FormatBool(boolean)
+	case domain.AttributeTypeEnum:
+		text, ok := raw.(string)
+		if !ok || !slices.Contains(attribute.Options, text) {
+			return value, web.NewBadRequestError(web.CodeAttributeValueInvalid, "attribute {0} must be one of {1}", attribute.Name, strings.Join(attribute.Options, ", "))
+		}
+
+		value.Value = text
+	default:
+		text, ok := raw.(string)
+		if !ok {
+			return value, web.NewBadRequestError(web.CodeAttributeValueInvalid, "attribute {0} must be a string", attribute.Name)
+		}
+
+		if len(text) > 255 {
+			return value, web.NewBadRequestError(web.CodeAttributeValueInvalid, "attribute {0} must be at most 255 characters", attribute.Name)
+		}
+
+		value.Value = text
+	}
+
+	return value, nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"inventory-management-system/repository"
+	"inventory-management-system/tracing"
+	"time"
+)
+
+var errUnitChanged = errors.New("unit status changed")
+
+type ItemUnitService interface {
+	Add(ctx context.Context, itemUnitAddRequest web.ItemUnitAddRequest, username string) (domain.ItemUnits, web.ErrorResponse)
+	Update(ctx context.Context, itemUnitUpdateRequest web.ItemUnitUpdateRequest, username string) web.ErrorResponse
+	GetByID(ctx context.Context, unitID int) (domain.ItemUnits, web.ErrorResponse)
+	GetByItemID(ctx context.Context, itemID int) ([]domain.ItemUnits, web.ErrorResponse)
+	GetBySerialNumber(ctx context.Context, serialNumber string) (domain.ItemUnits, web.ErrorResponse)
+	GetByAssetTag(ctx context.Context, assetTag string) (domain.ItemUnits, web.ErrorResponse)
+}
+
+type itemUnitServiceImpl struct {
+	repository.Transactor
+	repository.ItemUnitRepository
+	repository.ItemRepository
+	repository.ActivityRepository
+}
+
+func NewItemUnitService(transactor repository.Transactor, itemUnitRepository repository.ItemUnitRepository, itemRepository repository.ItemRepository, activityRepository repository.ActivityRepository) ItemUnitService {
+	return &itemUnitServiceImpl{transactor, itemUnitRepository, itemRepository, activityRepository}
+}
+
+func (i *itemUnitServiceImpl) Add(ctx context.Context, itemUnitAddRequest web.ItemUnitAddRequest, username string) (domain.ItemUnits, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "ItemUnitService.Add")
+	defer span.End()
+
+	item, err := i.ItemRepository.FindByID(ctx, itemUnitAddRequest.ItemID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.ItemUnits{}, web.NewNotFoundError(web.CodeItemNotFound, "item id not found")
+	}
+	if err != nil {
+		return domain.ItemUnits{}, internalError(ctx, err)
+	}
+
+	if !item.Serialized {
+		return domain.ItemUnits{}, web.NewBadRequestError(web.CodeItemNotSerialized, "item is not serialized")
+	}
+
+	if _, err := i.ItemUnitRepository.FindBySerialNumber(ctx, itemUnitAddRequest.SerialNumber); err == nil {
+		return domain.ItemUnits{}, web.NewConflictError(web.CodeSerialNumberTaken, "serial number is already in use")
+	}
+
+	if _, err := i.ItemUnitRepository.FindByAssetTag(ctx, itemUnitAddRequest.AssetTag); err == nil {
+		return domain.ItemUnits{}, web.NewConflictError(web.CodeAssetTagTaken, "asset tag is already in use")
+	}
+
+	unit := domain.ItemUnits{
+		ItemID:       item.ID,
+		SerialNumber: itemUnitAddRequest.SerialNumber,
+		AssetTag:     itemUnitAddRequest.AssetTag,
+		Status:       domain.UnitStatusInStock,
+		Note:         itemUnitAddRequest.Note,
+	}
+	err = i.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := i.ItemUnitRepository.Create(ctx, &unit); err != nil {
+			return err
+		}
+
+		if err := i.ItemRepository.SyncUnitStock(ctx, item.ID); err != nil {
+			return err
+		}
+
+		return i.ActivityRepository.Create(ctx, &domain.Activities{
+			ItemID:         item.ID,
+			UnitID:         &unit.ID,
+			Action:         domain.ActionUnitAdd,
+			QuantityChange: 1,
+			Timestamp:      time.Now(),
+			PerformedBy:    username,
+			Note:           "unit " + unit.SerialNumber + " added",
+		})
+	})
+	if errors.Is(err, repository.ErrDuplicate) {
+		return domain.ItemUnits{}, web.NewConflictError(web.CodeUnitIdentifierTaken, "serial number or asset tag is already in use")
+	}
+	if err != nil {
+		return domain.ItemUnits{}, internalError(ctx, err)
+	}
+
+	return unit, nil
+}
+
+func (i *itemUnitServiceImpl) Update(ctx context.Context, itemUnitUpdateRequest web.ItemUnitUpdateRequest, username string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "ItemUnitService.Update")
+	defer span.End()
+
+	unit, err := i.ItemUnitRepository.FindByID(ctx, itemUnitUpdateRequest.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewNotFoundError(web.CodeUnitNotFound, "unit id not found")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	if unit.Status == domain.UnitStatusAssigned {
+		return web.NewConflictError(web.CodeUnitAssigned, "unit is assigned, check it in first")
+	}
+
+	if unit.Status == itemUnitUpdateRequest.Status {
+		return web.NewConflictError(web.CodeUnitStatusUnchanged, "unit already has status {0}", unit.Status)
+	}
+
+	quantityChange := 0
+	if unit.Status == domain.UnitStatusInStock {
+		quantityChange = -1
+	} else if itemUnitUpdateRequest.Status == domain.UnitStatusInStock {
+		quantityChange = 1
+	}
+
+	note := fmt.Sprintf("unit %s %s -> %s", unit.SerialNumber, unit.Status, itemUnitUpdateRequest.Status)
+	if itemUnitUpdateRequest.Note != "" {
+		note += ": " + itemUnitUpdateRequest.Note
+	}
+
+	err = i.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := i.ItemUnitRepository.Transition(ctx, &domain.ItemUnits{
+			ID:     unit.ID,
+			Status: itemUnitUpdateRequest.Status,
+			Note:   itemUnitUpdateRequest.Note,
+		}, unit.Status)
+		if errors.Is(err, repository.ErrNotFound) {
+			return errUnitChanged
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := i.ItemRepository.SyncUnitStock(ctx, unit.ItemID); err != nil {
+			return err
+		}
+
+		return i.ActivityRepository.Create(ctx, &domain.Activities{
+			ItemID:         unit.ItemID,
+			UnitID:         &unit.ID,
+			Action:         domain.ActionUnitStatus,
+			QuantityChange: quantityChange,
+			Timestamp:      time.Now(),
+			PerformedBy:    username,
+			Note:           note,
+		})
+	})
+	if errors.Is(err, errUnitChanged) {
+		return web.NewConflictError(web.CodeUnitStatusChanged, "unit status has changed, try again")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	return nil
+}
+
+func (i *itemUnitServiceImpl) GetByID(ctx context.Context, unitID int) (domain.ItemUnits, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "ItemUnitService.GetByID")
+	defer span.End()
+
+	unit, err := i.ItemUnitRepository.FindByID(ctx, unitID)
+	return unitResult(ctx, unit, err)
+}
+
+func (i *itemUnitServiceImpl) GetByItemID(ctx context.Context, itemID int) ([]domain.ItemUnits, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "ItemUnitService.GetByItemID")
+	defer span.End()
+
+	units, err := i.ItemUnitRepository.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	if len(units) == 0 {
+		return nil, web.NewNotFoundError(web.CodeUnitNotFound, "unit not found")
+	}
+
+	return units, nil
+}
+
+func (i *itemUnitServiceImpl) GetBySerialNumber(ctx context.Context, serialNumber string) (domain.ItemUnits, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "ItemUnitService.GetBySerialNumber")
+	defer span.End()
+
+	unit, err := i.ItemUnitRepository.FindBySerialNumber(ctx, serialNumber)
+	return unitResult(ctx, unit, err)
+}
+
+func (i *itemUnitServiceImpl) GetByAssetTag(ctx context.Context, assetTag string) (domain.ItemUnits, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "ItemUnitService.GetByAssetTag")
+	defer span.End()
+
+	unit, err := i.ItemUnitRepository.FindByAssetTag(ctx, assetTag)
+	return unitResult(ctx, unit, err)
+}
+
+func unitResult(ctx context.Context, unit domain.ItemUnits, err error) (domain.ItemUnits, web.ErrorResponse) {
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.ItemUnits{}, web.NewNotFoundError(web.CodeUnitNotFound, "unit not found")
+	}
+	if err != nil {
+		return domain.ItemUnits{}, internalError(ctx, err)
+	}
+
+	return unit, nil
+}
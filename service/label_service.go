@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-management-system/label"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"inventory-management-system/repository"
+	"inventory-management-system/tracing"
+	"strconv"
+)
+
+type LabelService interface {
+	ItemLabel(ctx context.Context, itemID int) (label.Label, web.ErrorResponse)
+	UnitLabel(ctx context.Context, unitID int) (label.Label, web.ErrorResponse)
+	BatchLabels(ctx context.Context, labelBatchRequest web.LabelBatchRequest) ([]label.Label, web.ErrorResponse)
+	Resolve(ctx context.Context, code string) (domain.ScanResult, web.ErrorResponse)
+}
+
+type labelServiceImpl struct {
+	repository.ItemRepository
+	repository.ItemUnitRepository
+}
+
+func NewLabelService(itemRepository repository.ItemRepository, itemUnitRepository repository.ItemUnitRepository) LabelService {
+	return &labelServiceImpl{itemRepository, itemUnitRepository}
+}
+
+func (l *labelServiceImpl) ItemLabel(ctx context.Context, itemID int) (label.Label, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "LabelService.ItemLabel")
+	defer span.End()
+
+	item, err := l.ItemRepository.FindByID(ctx, itemID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return label.Label{}, web.NewNotFoundError(web.CodeItemNotFound, "item id {0} not found", strconv.Itoa(itemID))
+	}
+	if err != nil {
+		return label.Label{}, internalError(ctx, err)
+	}
+
+	return label.Label{
+		Code:     label.ItemCode(item.ID),
+		Title:    item.Name,
+		Subtitle: fmt.Sprintf("Item #%d", item.ID),
+	}, nil
+}
+
+func (l *labelServiceImpl) UnitLabel(ctx context.Context, unitID int) (label.Label, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "LabelService.UnitLabel")
+	defer span.End()
+
+	unit, err := l.ItemUnitRepository.FindByID(ctx, unitID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return label.Label{}, web.NewNotFoundError(web.CodeUnitNotFound, "unit id {0} not found", strconv.Itoa(unitID))
+	}
+	if err != nil {
+		return label.Label{}, internalError(ctx, err)
+	}
+
+	item, err := l.ItemRepository.FindByID(ctx, unit.ItemID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return label.Label{}, web.NewNotFoundError(web.CodeItemNotFound, "item id {0} not found", strconv.Itoa(unit.ItemID))
+	}
+	if err != nil {
+		return label.Label{}, internalError(ctx, err)
+	}
+
+	return label.Label{
+		Code:     unit.AssetTag,
+		Title:    item.Name,
+		Subtitle: "S/N " + unit.SerialNumber,
+	}, nil
+}
+
+func (l *labelServiceImpl) BatchLabels(ctx context.Context, labelBatchRequest web.LabelBatchRequest) ([]label.Label, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "LabelService.BatchLabels")
+	defer span.End()
+
+	labels := make([]label.Label, 0, len(labelBatchRequest.ItemIDs)+len(labelBatchRequest.UnitIDs))
+	for _, itemID := range labelBatchRequest.ItemIDs {
+		itemLabel, errResponse := l.ItemLabel(ctx, itemID)
+		if errResponse != nil {
+			return nil, errResponse
+		}
+		labels = append(labels, itemLabel)
+	}
+
+	for _, unitID := range labelBatchRequest.UnitIDs {
+		unitLabel, errResponse := l.UnitLabel(ctx, unitID)
+		if errResponse != nil {
+			return nil, errResponse
+		}
+		labels = append(labels, unitLabel)
+	}
+
+	return labels, nil
+}
+
+func (l *labelServiceImpl) Resolve(ctx context.Context, code string) (domain.ScanResult, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "LabelService.Resolve")
+	defer span.End()
+
+	result := domain.ScanResult{Code: code}
+	if itemID, ok := label.ParseItemCode(code); ok {
+		item, err := l.ItemRepository.FindByID(ctx, itemID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return domain.ScanResult{}, web.NewNotFoundError(web.CodeItemNotFound, "item not found")
+		}
+		if err != nil {
+			return domain.ScanResult{}, internalError(ctx, err)
+		}
+
+		result.Type = "item"
+		result.Item = item
+		return result, nil
+	}
+
+	unit, err := l.ItemUnitRepository.FindByAssetTag(ctx, code)
+	if errors.Is(err, repository.ErrNotFound) {
+		unit, err = l.ItemUnitRepository.FindBySerialNumber(ctx, code)
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.ScanResult{}, web.NewNotFoundError(web.CodeCodeNotRecognized, "code not recognized")
+	}
+	if err != nil {
+		return domain.ScanResult{}, internalError(ctx, err)
+	}
+
+	result.Item, err = l.ItemRepository.FindByID(ctx, unit.ItemID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.ScanResult{}, web.NewNotFoundError(web.CodeItemNotFound, "item not found")
+	}
+	if err != nil {
+		return domain.ScanResult{}, internalError(ctx, err)
+	}
+
+	result.Type = "unit"
+	result.Unit = &unit
+	return result, nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang-jwt/jwt"
+	"inventory-management-system/logging"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"inventory-management-system/oidc"
+	"inventory-management-system/tracing"
+)
+
+var rolePriority = []string{"admin", "user"}
+
+type OIDCService interface {
+	Begin(ctx context.Context) (domain.OIDCAuthRequest, web.ErrorResponse)
+	Callback(ctx context.Context, code string, verifier string, nonce string, ipAddress string) (domain.TokenPair, *domain.MFAChallenge, web.ErrorResponse)
+}
+
+type oidcServiceImpl struct {
+	oidc.Provider
+	UserService
+	groupsClaim string
+	groupRoles  map[string]string
+	defaultRole string
+}
+
+func NewOIDCService(provider oidc.Provider, userService UserService, groupsClaim string, groupRoles map[string]string, defaultRole string) OIDCService {
+	return &oidcServiceImpl{provider, userService, groupsClaim, groupRoles, defaultRole}
+}
+
+func (o *oidcServiceImpl) Begin(ctx context.Context) (domain.OIDCAuthRequest, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "OIDCService.Begin")
+	defer span.End()
+
+	state, err := randomName()
+	if err != nil {
+		return domain.OIDCAuthRequest{}, internalError(ctx, err)
+	}
+
+	nonce, err := randomName()
+	if err != nil {
+		return domain.OIDCAuthRequest{}, internalError(ctx, err)
+	}
+
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		return domain.OIDCAuthRequest{}, internalError(ctx, err)
+	}
+
+	url, err := o.Provider.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return domain.OIDCAuthRequest{}, internalError(ctx, err)
+	}
+
+	return domain.OIDCAuthRequest{URL: url, State: state, Nonce: nonce, Verifier: verifier}, nil
+}
+
+func (o *oidcServiceImpl) Callback(ctx context.Context, code string, verifier string, nonce string, ipAddress string) (domain.TokenPair, *domain.MFAChallenge, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "OIDCService.Callback")
+	defer span.End()
+
+	claims, err := o.Provider.Exchange(ctx, code, verifier, nonce)
+	var tokenErr *oidc.TokenError
+	if errors.As(err, &tokenErr) || errors.Is(err, oidc.ErrInvalidToken) || errors.Is(err, oidc.ErrUnknownKey) {
+		logging.FromContext(ctx).WarnContext(ctx, "sso login rejected", "error", err)
+		return domain.TokenPair{}, nil, web.NewUnauthorizedError(web.CodeSSOLoginFailed, "sso login failed")
+	}
+	if err != nil {
+		return domain.TokenPair{}, nil, internalError(ctx, err)
+	}
+
+	identity := domain.Identity{
+		Subject:       stringClaim(claims, "sub"),
+		Username:      stringClaim(claims, "preferred_username"),
+		Email:         stringClaim(claims, "email"),
+		EmailVerified: claims["email_verified"] == true || claims["email_verified"] == "true",
+		FullName:      stringClaim(claims, "name"),
+		Role:          o.role(claims),
+	}
+
+	if identity.Role == "" {
+		return domain.TokenPair{}, nil, web.NewForbiddenError(web.CodeIdentityNotAllowed, "identity is not allowed to sign in")
+	}
+
+	return o.UserService.LoginWithIdentity(ctx, identity, ipAddress)
+}
+
+func (o *oidcServiceImpl) role(claims jwt.MapClaims) string {
+	var groups []string
+	switch value := claims[o.groupsClaim].(type) {
+	case string:
+		groups = []string{value}
+	case []any:
+		for _, group := range value {
+			if group, ok := group.(string); ok {
+				groups = append(groups, group)
+			}
+		}
+	}
+
+	mapped := map[string]bool{}
+	for _, group := range groups {
+		if role, ok := o.groupRoles[group]; ok {
+			mapped[role] = true
+		}
+	}
+
+	for _, role := range rolePriority {
+		if mapped[role] {
+			return role
+		}
+	}
+
+	return o.defaultRole
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"inventory-management-system/config"
+	"inventory-management-system/helper"
+	"inventory-management-system/logging"
+	"inventory-management-system/mail"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"inventory-management-system/password"
+	"inventory-management-system/repository"
+	"inventory-management-system/tracing"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type PasswordResetService interface {
+	Request(ctx context.Context, email string) web.ErrorResponse
+	Confirm(ctx context.Context, passwordResetConfirmRequest web.PasswordResetConfirmRequest) web.ErrorResponse
+	Drain(ctx context.Context) error
+}
+
+type passwordResetServiceImpl struct {
+	repository.Transactor
+	repository.UserRepository
+	repository.SessionRepository
+	repository.PasswordResetRepository
+	mailer         mail.Mailer
+	passwordPolicy password.Policy
+	passwords      config.Password
+	mailTimeout    time.Duration
+	slots          chan struct{}
+	pending        sync.WaitGroup
+}
+
+func NewPasswordResetService(transactor repository.Transactor, userRepository repository.UserRepository, sessionRepository repository.SessionRepository, passwordResetRepository repository.PasswordResetRepository, mailer mail.Mailer, passwordPolicy password.Policy, passwords config.Password, mails config.Mail) PasswordResetService {
+	return &passwordResetServiceImpl{
+		Transactor:              transactor,
+		UserRepository:          userRepository,
+		SessionRepository:       sessionRepository,
+		PasswordResetRepository: passwordResetRepository,
+		mailer:                  mailer,
+		passwordPolicy:          passwordPolicy,
+		passwords:               passwords,
+		mailTimeout:             mails.Timeout,
+		slots:                   make(chan struct{}, mails.MaxPending),
+	}
+}
+
+func (p *passwordResetServiceImpl) Request(ctx context.Context, email string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "PasswordResetService.Request")
+	defer span.End()
+
+	user, err := p.UserRepository.FindByEmail(ctx, strings.ToLower(email))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	if !user.Active {
+		return nil
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+	default:
+		logging.FromContext(ctx).WarnContext(ctx, "too many pending password reset mails, dropping request", "username", user.Username)
+		return nil
+	}
+
+	p.pending.Add(1)
+	go func() {
+		defer p.pending.Done()
+		defer func() { <-p.slots }()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), p.mailTimeout)
+		defer cancel()
+		p.issue(ctx, user)
+	}()
+	return nil
+}
+
+func (p *passwordResetServiceImpl) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		p.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *passwordResetServiceImpl) issue(ctx context.Context, user domain.Users) {
+	ctx, span := tracing.Start(ctx, "PasswordResetService.issue")
+	defer span.End()
+
+	token, err := randomName()
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to create password reset token", "error", err)
+		return
+	}
+
+	now := time.Now()
+	err = p.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := p.PasswordResetRepository.InvalidateByUsername(ctx, user.Username, now); err != nil {
+			return err
+		}
+
+		return p.PasswordResetRepository.Create(ctx, &domain.PasswordResets{
+			Username:  user.Username,
+			TokenHash: hashToken(token),
+			ExpiresAt: now.Add(p.passwords.ResetTokenTTL),
+		})
+	})
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to store password reset token", "error", err)
+		return
+	}
+
+	if err := p.mailer.Send(ctx, p.resetMessage(user, token)); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to send password reset mail", "error", err)
+	}
+}
+
+func (p *passwordResetServiceImpl) Confirm(ctx context.Context, passwordResetConfirmRequest web.PasswordResetConfirmRequest) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "PasswordResetService.Confirm")
+	defer span.End()
+
+	passwordReset, err := p.PasswordResetRepository.FindByTokenHash(ctx, hashToken(passwordResetConfirmRequest.Token))
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewBadRequestError(web.CodeResetTokenInvalid, "reset token is invalid or expired")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	now := time.Now()
+	if passwordReset.UsedAt != nil || !now.Before(passwordReset.ExpiresAt) {
+		return web.NewBadRequestError(web.CodeResetTokenInvalid, "reset token is invalid or expired")
+	}
+
+	if err := p.passwordPolicy.Check(passwordResetConfirmRequest.NewPassword); err != nil {
+		return weakPasswordError(err)
+	}
+
+	hashedPassword, err := helper.HashPassword(passwordResetConfirmRequest.NewPassword)
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	err = p.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := p.PasswordResetRepository.MarkUsed(ctx, passwordReset.ID, now); err != nil {
+			return err
+		}
+
+		if err := p.UserRepository.Update(ctx, &domain.Users{Username: passwordReset.Username, Password: hashedPassword}); err != nil {
+			return err
+		}
+
+		if err := p.UserRepository.SetLoginState(ctx, passwordReset.Username, 0, nil); err != nil {
+			return err
+		}
+
+		return p.SessionRepository.RevokeByUsername(ctx, passwordReset.Username, now)
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewBadRequestError(web.CodeResetTokenInvalid, "reset token is invalid or expired")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	return nil
+}
+
+func (p *passwordResetServiceImpl) resetMessage(user domain.Users, token string) mail.Message {
+	link := token
+	if p.passwords.ResetURL != "" {
+		separator := "?"
+		if strings.Contains(p.passwords.ResetURL, "?") {
+			separator = "&"
+		}
+		link = p.passwords.ResetURL + separator + "token=" + url.QueryEscape(token)
+	}
+
+	name := user.FullName
+	if name == "" {
+		name = user.Username
+	}
+
+	return mail.Message{
+		To:      *user.Email,
+		Subject: "Reset your password",
+		Body: "Hello " + name + ",\n\n" +
+			"Someone asked to reset the password of your account " + user.Username + ".\n" +
+			"Use the following to choose a new password. It expires in " + p.passwords.ResetTokenTTL.String() + " and works only once:\n\n" +
+			link + "\n\n" +
+			"If you did not ask for this, you can ignore this email.\n",
+	}
+}
@@ -1,46 +1,54 @@
 package service
 
 import (
+	"context"
 	"inventory-management-system/model/domain"
 	"inventory-management-system/model/web"
 	"inventory-management-system/repository"
+	"inventory-management-system/tracing"
 )
 
 type ReportService interface {
-	GetAllActivity() ([]domain.Activities, web.ErrorResponse)
-	ReportStock(stockItem int) ([]domain.Items, web.ErrorResponse)
+	GetAllActivity(ctx context.Context) ([]domain.Activities, web.ErrorResponse)
+	ReportStock(ctx context.Context, stockItem int) ([]domain.Items, web.ErrorResponse)
 }
 
 type reportServiceImpl struct {
-	repository.HandlerRepository
+	repository.ActivityRepository
+	repository.ItemRepository
 }
 
-func NewReportService(handleRepository repository.HandlerRepository) ReportService {
-	return &reportServiceImpl{handleRepository}
+func NewReportService(activityRepository repository.ActivityRepository, itemRepository repository.ItemRepository) ReportService {
+	return &reportServiceImpl{activityRepository, itemRepository}
 }
 
-func (r *reportServiceImpl) GetAllActivity() ([]domain.Activities, web.ErrorResponse) {
-	activities := []domain.Activities{}
-	err := r.HandlerRepository.GetAll(&activities)
+func (r *reportServiceImpl) GetAllActivity(ctx context.Context) ([]domain.Activities, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "ReportService.GetAllActivity")
+	defer span.End()
+
+	activities, err := r.ActivityRepository.FindAll(ctx)
 	if err != nil {
-		return nil, web.NewInternalServerErrorError(err.Error())
+		return nil, internalError(ctx, err)
 	}
 
 	if len(activities) == 0 {
-		return nil, web.NewNotFoundError("report not found")
+		return nil, web.NewNotFoundError(web.CodeReportNotFound, "report not found")
 	}
 
 	return activities, nil
 }
 
-func (r *reportServiceImpl) ReportStock(stockItem int) ([]domain.Items, web.ErrorResponse) {
-	items, err := r.HandlerRepository.ReportStock(stockItem)
+func (r *reportServiceImpl) ReportStock(ctx context.Context, stockItem int) ([]domain.Items, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "ReportService.ReportStock")
+	defer span.End()
+
+	items, err := r.ItemRepository.FindByMaxQuantity(ctx, stockItem)
 	if err != nil {
-		return nil, web.NewInternalServerErrorError(err.Error())
+		return nil, internalError(ctx, err)
 	}
 
 	if len(items) == 0 {
-		return nil, web.NewNotFoundError("report not found")
+		return nil, web.NewNotFoundError(web.CodeReportNotFound, "report not found")
 	}
 
 	return items, nil
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"inventory-management-system/config"
+	"inventory-management-system/label"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"inventory-management-system/repository"
+	"inventory-management-system/totp"
+	"inventory-management-system/tracing"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	recoveryCodeCount = 10
+	qrCodeSize        = 256
+)
+
+type TwoFactorService interface {
+	Enroll(ctx context.Context, username string) (domain.TOTPEnrollment, web.ErrorResponse)
+	Confirm(ctx context.Context, username string, code string) ([]string, web.ErrorResponse)
+	Disable(ctx context.Context, username string, code string) web.ErrorResponse
+	Reset(ctx context.Context, username string) web.ErrorResponse
+}
+
+type twoFactorServiceImpl struct {
+	repository.UserRepository
+	mfa config.MFA
+}
+
+func NewTwoFactorService(userRepository repository.UserRepository, mfa config.MFA) TwoFactorService {
+	return &twoFactorServiceImpl{userRepository, mfa}
+}
+
+func (t *twoFactorServiceImpl) Enroll(ctx context.Context, username string) (domain.TOTPEnrollment, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.Enroll")
+	defer span.End()
+
+	user, errResponse := t.findUser(ctx, username)
+	if errResponse != nil {
+		return domain.TOTPEnrollment{}, errResponse
+	}
+
+	if user.TOTPEnabled {
+		return domain.TOTPEnrollment{}, web.NewConflictError(web.CodeTwoFactorAlreadyEnabled, "two-factor authentication is already enabled")
+	}
+
+	enrollment, err := beginEnrollment(ctx, t.UserRepository, t.mfa.Issuer, user.Username)
+	if err != nil {
+		return domain.TOTPEnrollment{}, internalError(ctx, err)
+	}
+
+	return enrollment, nil
+}
+
+func (t *twoFactorServiceImpl) Confirm(ctx context.Context, username string, code string) ([]string, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.Confirm")
+	defer span.End()
+
+	user, errResponse := t.findUser(ctx, username)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	if user.TOTPEnabled {
+		return nil, web.NewConflictError(web.CodeTwoFactorAlreadyEnabled, "two-factor authentication is already enabled")
+	}
+
+	if user.TOTPSecret == "" {
+		return nil, web.NewConflictError(web.CodeTwoFactorEnrollmentMissing, "two-factor enrollment has not been started")
+	}
+
+	recoveryCodes, ok, err := confirmEnrollment(ctx, t.UserRepository, user, code)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+	if !ok {
+		return nil, web.NewBadRequestError(web.CodeTwoFactorCodeInvalid, "invalid two-factor code")
+	}
+
+	return recoveryCodes, nil
+}
+
+func (t *twoFactorServiceImpl) Disable(ctx context.Context, username string, code string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.Disable")
+	defer span.End()
+
+	user, errResponse := t.findUser(ctx, username)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if !user.TOTPEnabled {
+		return web.NewConflictError(web.CodeTwoFactorNotEnabled, "two-factor authentication is not enabled")
+	}
+
+	if slices.Contains(t.mfa.RequiredRoles, user.Role) {
+		return web.NewBadRequestError(web.CodeTwoFactorRequired, "two-factor authentication is required for role {0}", user.Role)
+	}
+
+	ok, err := verifySecondFactor(ctx, t.UserRepository, user, code)
+	if err != nil {
+		return internalError(ctx, err)
+	}
+	if !ok {
+		return web.NewBadRequestError(web.CodeTwoFactorCodeInvalid, "invalid two-factor code")
+	}
+
+	if err := t.UserRepository.SetTwoFactor(ctx, user.Username, domain.TwoFactor{}); err != nil {
+		return internalError(ctx, err)
+	}
+
+	return nil
+}
+
+func (t *twoFactorServiceImpl) Reset(ctx context.Context, username string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "TwoFactorService.Reset")
+	defer span.End()
+
+	user, errResponse := t.findUser(ctx, username)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if err := t.UserRepository.SetTwoFactor(ctx, user.Username, domain.TwoFactor{}); err != nil {
+		return internalError(ctx, err)
+	}
+
+	return nil
+}
+
+func (t *twoFactorServiceImpl) findUser(ctx context.Context, username string) (domain.Users, web.ErrorResponse) {
+	user, err := t.UserRepository.FindByUsername(ctx, username)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Users{}, web.NewNotFoundError(web.CodeUserNotFound, "user not found")
+	}
+	if err != nil {
+		return domain.Users{}, internalError(ctx, err)
+	}
+
+	return user, nil
+}
+
+func beginEnrollment(ctx context.Context, users repository.UserRepository, issuer string, username string) (domain.TOTPEnrollment, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return domain.TOTPEnrollment{}, err
+	}
+
+	if err := users.SetTwoFactor(ctx, username, domain.TwoFactor{TOTPSecret: secret}); err != nil {
+		return domain.TOTPEnrollment{}, err
+	}
+
+	uri := totp.URI(issuer, username, secret)
+	qrCode, err := label.QRCode(uri, qrCodeSize)
+	if err != nil {
+		return domain.TOTPEnrollment{}, err
+	}
+
+	return domain.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: uri,
+		QRCode:          "data:image/png;base64," + base64.StdEncoding.EncodeToString(qrCode),
+	}, nil
+}
+
+func confirmEnrollment(ctx context.Context, users repository.UserRepository, user domain.Users, code string) ([]string, bool, error) {
+	step, ok := totp.Validate(user.TOTPSecret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return nil, false, nil
+	}
+
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		code, err := randomName()
+		if err != nil {
+			return nil, false, err
+		}
+
+		code = code[:5] + "-" + code[5:10]
+		recoveryCodes = append(recoveryCodes, code)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+
+	twoFactor := domain.TwoFactor{TOTPSecret: user.TOTPSecret, TOTPEnabled: true, TOTPLastStep: step, RecoveryCodes: hashes}
+	if err := users.SetTwoFactor(ctx, user.Username, twoFactor); err != nil {
+		return nil, false, err
+	}
+
+	return recoveryCodes, true, nil
+}
+
+func verifySecondFactor(ctx context.Context, users repository.UserRepository, user domain.Users, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if step, ok := totp.Validate(user.TOTPSecret, code, time.Now()); ok {
+		err := users.UseTOTPStep(ctx, user.Username, step)
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	hash := hashToken(normalizeRecoveryCode(code))
+	recoveryCodes := user.RecoveryCodes
+	for {
+		index := slices.Index(recoveryCodes, hash)
+		if index < 0 {
+			return false, nil
+		}
+
+		remaining := slices.Delete(slices.Clone(recoveryCodes), index, index+1)
+		err := users.ReplaceRecoveryCodes(ctx, user.Username, recoveryCodes, remaining)
+		if !errors.Is(err, repository.ErrNotFound) {
+			return err == nil, err
+		}
+
+		current, err := users.FindByUsername(ctx, user.Username)
+		if err != nil {
+			return false, err
+		}
+		recoveryCodes = current.RecoveryCodes
+	}
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
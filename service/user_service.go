@@ -1,147 +1,674 @@
 package service
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"github.com/golang-jwt/jwt"
+	"inventory-management-system/config"
 	"inventory-management-system/helper"
+	"inventory-management-system/logging"
 	"inventory-management-system/model/domain"
 	"inventory-management-system/model/web"
+	"inventory-management-system/password"
+	"inventory-management-system/ratelimit"
 	"inventory-management-system/repository"
+	"inventory-management-system/signing"
+	"inventory-management-system/tracing"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 )
 
+var errLastAdmin = errors.New("last active admin")
+
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := helper.HashPassword("dummy-password")
+	return hash
+})
+
 type UserService interface {
-	Register(userRegisterRequest *web.UserRegisterRequest) web.ErrorResponse
-	Login(userLoginRequest *web.UserLoginRequest) (*string, web.ErrorResponse)
-	Logout() web.ErrorResponse
-	Update(userUpdateRequest web.UserUpdateRequest) web.ErrorResponse
-	Delete(username string) web.ErrorResponse
-	GetAll() ([]domain.Users, web.ErrorResponse)
-	GetByUsername(username string) (domain.Users, web.ErrorResponse)
-	CheckAvailable(username string) bool
+	Register(ctx context.Context, userRegisterRequest *web.UserRegisterRequest) web.ErrorResponse
+	Login(ctx context.Context, userLoginRequest *web.UserLoginRequest, ipAddress string) (domain.TokenPair, *domain.MFAChallenge, web.ErrorResponse)
+	EnrollMFA(ctx context.Context, mfaToken string) (domain.TOTPEnrollment, web.ErrorResponse)
+	VerifyMFA(ctx context.Context, mfaVerifyRequest web.MFAVerifyRequest, ipAddress string) (domain.TokenPair, web.ErrorResponse)
+	LoginWithIdentity(ctx context.Context, identity domain.Identity, ipAddress string) (domain.TokenPair, *domain.MFAChallenge, web.ErrorResponse)
+	Refresh(ctx context.Context, refreshToken string) (domain.TokenPair, web.ErrorResponse)
+	VerifySession(ctx context.Context, accessToken string) (domain.Users, web.ErrorResponse)
+	Logout(ctx context.Context, accessToken string) web.ErrorResponse
+	Update(ctx context.Context, userUpdateRequest web.UserUpdateRequest) web.ErrorResponse
+	Delete(ctx context.Context, username string, performedBy string) web.ErrorResponse
+	Deactivate(ctx context.Context, username string, performedBy string) web.ErrorResponse
+	Reactivate(ctx context.Context, username string) web.ErrorResponse
+	GetAll(ctx context.Context) ([]domain.Users, web.ErrorResponse)
+	GetByUsername(ctx context.Context, username string) (domain.Users, web.ErrorResponse)
+	CheckAvailable(ctx context.Context, username string) bool
+	GetLoginAttempts(ctx context.Context, username string) ([]domain.LoginAttempts, web.ErrorResponse)
+	UpdateProfile(ctx context.Context, username string, profileUpdateRequest web.ProfileUpdateRequest) web.ErrorResponse
+	ChangePassword(ctx context.Context, username string, passwordChangeRequest web.PasswordChangeRequest) (domain.TokenPair, web.ErrorResponse)
 }
 
 type userServiceImpl struct {
-	repository.HandlerRepository
+	repository.Transactor
+	repository.UserRepository
+	repository.SessionRepository
+	repository.LoginAttemptRepository
+	repository.ActivityRepository
+	usernameLimiter ratelimit.Limiter
+	maxFailures     int
+	lockoutDuration time.Duration
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	keys            signing.KeySet
+	mfa             config.MFA
+	passwordPolicy  password.Policy
 }
 
-func NewUserService(handlerRepository repository.HandlerRepository) UserService {
-	return &userServiceImpl{handlerRepository}
+func NewUserService(transactor repository.Transactor, userRepository repository.UserRepository, sessionRepository repository.SessionRepository, loginAttemptRepository repository.LoginAttemptRepository, activityRepository repository.ActivityRepository, usernameLimiter ratelimit.Limiter, maxFailures int, lockoutDuration time.Duration, accessTokenTTL time.Duration, refreshTokenTTL time.Duration, keys signing.KeySet, mfa config.MFA, passwordPolicy password.Policy) UserService {
+	return &userServiceImpl{transactor, userRepository, sessionRepository, loginAttemptRepository, activityRepository, usernameLimiter, maxFailures, lockoutDuration, accessTokenTTL, refreshTokenTTL, keys, mfa, passwordPolicy}
 }
 
-func (u *userServiceImpl) Register(userRegisterRequest *web.UserRegisterRequest) web.ErrorResponse {
+func (u *userServiceImpl) Register(ctx context.Context, userRegisterRequest *web.UserRegisterRequest) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "UserService.Register")
+	defer span.End()
+
+	if err := u.passwordPolicy.Check(userRegisterRequest.Password); err != nil {
+		return weakPasswordError(err)
+	}
+
 	hasPassword, err := helper.HashPassword(userRegisterRequest.Password)
 	if err != nil {
-		return web.NewInternalServerErrorError("failed to hash password")
+		return internalError(ctx, err)
 	}
 
-	if u.CheckAvailable(userRegisterRequest.Username) {
-		return web.NewBadRequestError("username is already taken")
+	if u.CheckAvailable(ctx, userRegisterRequest.Username) {
+		return web.NewConflictError(web.CodeUsernameTaken, "username is already taken")
 	}
 
-	err = u.HandlerRepository.Add(&domain.Users{
+	email := optionalEmail(userRegisterRequest.Email)
+	if u.emailTaken(ctx, email, "") {
+		return web.NewConflictError(web.CodeEmailTaken, "email is already in use")
+	}
+
+	err = u.UserRepository.Create(ctx, &domain.Users{
 		FullName: userRegisterRequest.FullName,
 		Username: userRegisterRequest.Username,
 		Password: hasPassword,
 		Role:     userRegisterRequest.Role,
+		Email:    email,
+		Active:   true,
 	})
+	if errors.Is(err, repository.ErrDuplicate) && u.emailTaken(ctx, email, userRegisterRequest.Username) {
+		return web.NewConflictError(web.CodeEmailTaken, "email is already in use")
+	}
+	if errors.Is(err, repository.ErrDuplicate) {
+		return web.NewConflictError(web.CodeUsernameTaken, "username is already taken")
+	}
 	if err != nil {
-		return web.NewInternalServerErrorError(err.Error())
+		return internalError(ctx, err)
 	}
 
 	return nil
 }
 
-func (u *userServiceImpl) Login(userLoginRequest *web.UserLoginRequest) (*string, web.ErrorResponse) {
-	user := domain.Users{}
-	err := u.HandlerRepository.GetByUsername(userLoginRequest.Username, &user)
+func (u *userServiceImpl) Login(ctx context.Context, userLoginRequest *web.UserLoginRequest, ipAddress string) (domain.TokenPair, *domain.MFAChallenge, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "UserService.Login")
+	defer span.End()
+
+	if allowed, _ := u.usernameLimiter.Allow(userLoginRequest.Username); !allowed {
+		u.recordAttempt(ctx, userLoginRequest.Username, ipAddress, domain.LoginRateLimited)
+		return domain.TokenPair{}, nil, web.NewTooManyRequestsError(web.CodeTooManyLoginAttempts, "too many login attempts, try again later")
+	}
+
+	user, err := u.UserRepository.FindByUsername(ctx, userLoginRequest.Username)
+	if errors.Is(err, repository.ErrNotFound) {
+		helper.CheckPasswordHash(userLoginRequest.Password, dummyPasswordHash())
+		u.recordAttempt(ctx, userLoginRequest.Username, ipAddress, domain.LoginUnknownUser)
+		return domain.TokenPair{}, nil, web.NewBadRequestError(web.CodeInvalidCredentials, "invalid username or password")
+	}
 	if err != nil {
-		return nil, web.NewNotFoundError("user not found")
+		return domain.TokenPair{}, nil, internalError(ctx, err)
+	}
+
+	now := time.Now()
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		helper.CheckPasswordHash(userLoginRequest.Password, dummyPasswordHash())
+		u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginLocked)
+		return domain.TokenPair{}, nil, web.NewBadRequestError(web.CodeInvalidCredentials, "invalid username or password")
 	}
 
 	result := helper.CheckPasswordHash(userLoginRequest.Password, user.Password)
 	if !result {
-		return nil, web.NewBadRequestError("invalid username or password")
+		u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginInvalidPassword)
+		if errResponse := u.recordFailure(ctx, user.Username, now); errResponse != nil {
+			return domain.TokenPair{}, nil, errResponse
+		}
+		return domain.TokenPair{}, nil, web.NewBadRequestError(web.CodeInvalidCredentials, "invalid username or password")
 	}
 
-	expirationTime := time.Now().Add(20 * time.Minute)
-	claims := &domain.JwtCustomClaims{
-		Username: user.Username,
-		Role:     user.Role,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: expirationTime.Unix(),
-		},
+	if !user.Active {
+		u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginDeactivated)
+		return domain.TokenPair{}, nil, web.NewForbiddenError(web.CodeAccountDeactivated, "account is deactivated")
+	}
+
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		if err := u.UserRepository.SetLoginState(ctx, user.Username, 0, nil); err != nil {
+			return domain.TokenPair{}, nil, internalError(ctx, err)
+		}
+	}
+
+	if user.TOTPEnabled || slices.Contains(u.mfa.RequiredRoles, user.Role) {
+		challenge, err := u.mfaChallenge(user, now)
+		if err != nil {
+			return domain.TokenPair{}, nil, internalError(ctx, err)
+		}
+
+		u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginMFARequired)
+		return domain.TokenPair{}, &challenge, nil
 	}
 
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := t.SignedString(domain.JwtKey)
+	familyID, err := randomName()
 	if err != nil {
-		return nil, web.NewInternalServerErrorError(err.Error())
+		return domain.TokenPair{}, nil, internalError(ctx, err)
 	}
 
-	session := domain.Sessions{
-		Username:  user.Username,
-		Token:     tokenString,
-		ExpiresAt: expirationTime,
+	tokens, err := u.issueTokens(ctx, user, familyID)
+	if err != nil {
+		return domain.TokenPair{}, nil, internalError(ctx, err)
+	}
+
+	u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginSuccess)
+	return tokens, nil, nil
+}
+
+func (u *userServiceImpl) EnrollMFA(ctx context.Context, mfaToken string) (domain.TOTPEnrollment, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "UserService.EnrollMFA")
+	defer span.End()
+
+	user, errResponse := u.mfaUser(ctx, mfaToken)
+	if errResponse != nil {
+		return domain.TOTPEnrollment{}, errResponse
+	}
+
+	if user.TOTPEnabled {
+		return domain.TOTPEnrollment{}, web.NewConflictError(web.CodeTwoFactorAlreadyEnabled, "two-factor authentication is already enabled")
+	}
+
+	enrollment, err := beginEnrollment(ctx, u.UserRepository, u.mfa.Issuer, user.Username)
+	if err != nil {
+		return domain.TOTPEnrollment{}, internalError(ctx, err)
+	}
+
+	return enrollment, nil
+}
+
+func (u *userServiceImpl) VerifyMFA(ctx context.Context, mfaVerifyRequest web.MFAVerifyRequest, ipAddress string) (domain.TokenPair, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "UserService.VerifyMFA")
+	defer span.End()
+
+	user, errResponse := u.mfaUser(ctx, mfaVerifyRequest.MFAToken)
+	if errResponse != nil {
+		return domain.TokenPair{}, errResponse
 	}
 
-	err = u.HandlerRepository.Add(&session)
+	if !user.Active {
+		u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginDeactivated)
+		return domain.TokenPair{}, web.NewForbiddenError(web.CodeAccountDeactivated, "account is deactivated")
+	}
+
+	if allowed, _ := u.usernameLimiter.Allow(user.Username); !allowed {
+		u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginRateLimited)
+		return domain.TokenPair{}, web.NewTooManyRequestsError(web.CodeTooManyLoginAttempts, "too many login attempts, try again later")
+	}
+
+	now := time.Now()
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginLocked)
+		return domain.TokenPair{}, web.NewTooManyRequestsError(web.CodeTooManyLoginAttempts, "too many login attempts, try again later")
+	}
+
+	var recoveryCodes []string
+	var ok bool
+	var err error
+	switch {
+	case user.TOTPEnabled:
+		ok, err = verifySecondFactor(ctx, u.UserRepository, user, mfaVerifyRequest.Code)
+	case user.TOTPSecret != "":
+		recoveryCodes, ok, err = confirmEnrollment(ctx, u.UserRepository, user, mfaVerifyRequest.Code)
+	default:
+		return domain.TokenPair{}, web.NewBadRequestError(web.CodeTwoFactorEnrollmentRequired, "two-factor enrollment is required")
+	}
+	if err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	if !ok {
+		u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginInvalidMFA)
+		if errResponse := u.recordFailure(ctx, user.Username, now); errResponse != nil {
+			return domain.TokenPair{}, errResponse
+		}
+		return domain.TokenPair{}, web.NewUnauthorizedError(web.CodeTwoFactorCodeInvalid, "invalid two-factor code")
+	}
+
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		if err := u.UserRepository.SetLoginState(ctx, user.Username, 0, nil); err != nil {
+			return domain.TokenPair{}, internalError(ctx, err)
+		}
+	}
+
+	familyID, err := randomName()
+	if err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	tokens, err := u.issueTokens(ctx, user, familyID)
+	if err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	tokens.RecoveryCodes = recoveryCodes
+	u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginSuccess)
+	return tokens, nil
+}
+
+func (u *userServiceImpl) LoginWithIdentity(ctx context.Context, identity domain.Identity, ipAddress string) (domain.TokenPair, *domain.MFAChallenge, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "UserService.LoginWithIdentity")
+	defer span.End()
+
+	identity.Email = strings.ToLower(identity.Email)
+	user, err := u.UserRepository.FindByOIDCSubject(ctx, identity.Subject)
+	if errors.Is(err, repository.ErrNotFound) {
+		user, err = u.findLinkableUser(ctx, identity)
+	}
+
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		user, err = u.provisionUser(ctx, identity)
+		if errors.Is(err, repository.ErrDuplicate) {
+			u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginSSORejected)
+			return domain.TokenPair{}, nil, web.NewConflictError(web.CodeUsernameTaken, "username is already taken")
+		}
+		if err != nil {
+			return domain.TokenPair{}, nil, internalError(ctx, err)
+		}
+	case err != nil:
+		return domain.TokenPair{}, nil, internalError(ctx, err)
+	case user.OIDCSubject != nil && *user.OIDCSubject != identity.Subject:
+		u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginSSORejected)
+		return domain.TokenPair{}, nil, web.NewUnauthorizedError(web.CodeIdentityConflict, "account is linked to another identity")
+	case !user.Active:
+		u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginDeactivated)
+		return domain.TokenPair{}, nil, web.NewForbiddenError(web.CodeAccountDeactivated, "account is deactivated")
+	default:
+		email := user.Email
+		if identity.Email != "" && identity.EmailVerified {
+			email = &identity.Email
+		}
+
+		if user.OIDCSubject == nil || !equalEmail(user.Email, email) || user.Role != identity.Role {
+			err := u.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				if user.Role != identity.Role {
+					if err := u.ensureAnotherAdmin(ctx, user); err != nil {
+						return err
+					}
+				}
+
+				return u.UserRepository.SetIdentity(ctx, user.Username, identity.Subject, email, identity.Role)
+			})
+			if errors.Is(err, errLastAdmin) {
+				u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginSSORejected)
+				return domain.TokenPair{}, nil, web.NewConflictError(web.CodeLastActiveAdmin, "cannot demote the last active admin")
+			}
+			if errors.Is(err, repository.ErrDuplicate) {
+				u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginSSORejected)
+				return domain.TokenPair{}, nil, web.NewConflictError(web.CodeEmailTaken, "email is already in use")
+			}
+			if err != nil {
+				return domain.TokenPair{}, nil, internalError(ctx, err)
+			}
+			user.Email = email
+			user.Role = identity.Role
+		}
+	}
+
+	if user.TOTPEnabled || slices.Contains(u.mfa.RequiredRoles, user.Role) {
+		challenge, err := u.mfaChallenge(user, time.Now())
+		if err != nil {
+			return domain.TokenPair{}, nil, internalError(ctx, err)
+		}
+
+		u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginMFARequired)
+		return domain.TokenPair{}, &challenge, nil
+	}
+
+	familyID, err := randomName()
 	if err != nil {
-		return nil, web.NewInternalServerErrorError(err.Error())
+		return domain.TokenPair{}, nil, internalError(ctx, err)
 	}
 
-	return &tokenString, nil
+	tokens, err := u.issueTokens(ctx, user, familyID)
+	if err != nil {
+		return domain.TokenPair{}, nil, internalError(ctx, err)
+	}
+
+	u.recordAttempt(ctx, user.Username, ipAddress, domain.LoginSuccess)
+	return tokens, nil, nil
 }
 
-func (u *userServiceImpl) Logout() web.ErrorResponse {
-	//TODO implement me
-	panic("implement me")
+func (u *userServiceImpl) findLinkableUser(ctx context.Context, identity domain.Identity) (domain.Users, error) {
+	if identity.Email == "" || !identity.EmailVerified {
+		return domain.Users{}, repository.ErrNotFound
+	}
+
+	return u.UserRepository.FindByEmail(ctx, identity.Email)
 }
 
-func (u *userServiceImpl) Update(userUpdateRequest web.UserUpdateRequest) web.ErrorResponse {
-	if !u.CheckAvailable(userUpdateRequest.Username) {
-		return web.NewNotFoundError("user not found")
+func (u *userServiceImpl) provisionUser(ctx context.Context, identity domain.Identity) (domain.Users, error) {
+	username := identity.Username
+	if username == "" {
+		username = identity.Email
+	}
+	if username == "" {
+		username = identity.Subject
+	}
+
+	var email *string
+	if identity.EmailVerified {
+		email = optionalEmail(identity.Email)
+	}
+
+	subject := identity.Subject
+	user := domain.Users{
+		FullName:    identity.FullName,
+		Username:    username,
+		Role:        identity.Role,
+		Email:       email,
+		OIDCSubject: &subject,
+		Active:      true,
+	}
+
+	return user, u.UserRepository.Create(ctx, &user)
+}
+
+func (u *userServiceImpl) Refresh(ctx context.Context, refreshToken string) (domain.TokenPair, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "UserService.Refresh")
+	defer span.End()
+
+	session, err := u.SessionRepository.FindByRefreshTokenHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.TokenPair{}, web.NewUnauthorizedError(web.CodeRefreshTokenInvalid, "invalid refresh token")
+	}
+	if err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	now := time.Now()
+	if session.RevokedAt != nil {
+		return domain.TokenPair{}, web.NewUnauthorizedError(web.CodeRefreshTokenInvalid, "invalid refresh token")
+	}
+
+	if session.RotatedAt != nil {
+		return domain.TokenPair{}, u.revokeFamily(ctx, session, now)
+	}
+
+	if !now.Before(session.RefreshExpiresAt) {
+		return domain.TokenPair{}, web.NewUnauthorizedError(web.CodeRefreshTokenExpired, "refresh token expired")
+	}
+
+	user, err := u.UserRepository.FindByUsername(ctx, session.Username)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.TokenPair{}, web.NewUnauthorizedError(web.CodeRefreshTokenInvalid, "invalid refresh token")
+	}
+	if err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	if !user.Active {
+		return domain.TokenPair{}, web.NewForbiddenError(web.CodeAccountDeactivated, "account is deactivated")
+	}
+
+	err = u.SessionRepository.MarkRotated(ctx, session.ID, now)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.TokenPair{}, u.revokeFamily(ctx, session, now)
+	}
+	if err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	tokens, err := u.issueTokens(ctx, user, session.FamilyID)
+	if err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	return tokens, nil
+}
+
+func (u *userServiceImpl) VerifySession(ctx context.Context, accessToken string) (domain.Users, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "UserService.VerifySession")
+	defer span.End()
+
+	session, err := u.SessionRepository.FindByToken(ctx, accessToken)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Users{}, web.NewUnauthorizedError(web.CodeTokenInvalid, "token is invalid")
+	}
+	if err != nil {
+		return domain.Users{}, internalError(ctx, err)
+	}
+
+	if session.RevokedAt != nil {
+		return domain.Users{}, web.NewUnauthorizedError(web.CodeTokenRevoked, "token is revoked")
+	}
+
+	user, err := u.UserRepository.FindByUsername(ctx, session.Username)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Users{}, web.NewUnauthorizedError(web.CodeTokenInvalid, "token is invalid")
+	}
+	if err != nil {
+		return domain.Users{}, internalError(ctx, err)
+	}
+
+	if !user.Active {
+		return domain.Users{}, web.NewForbiddenError(web.CodeAccountDeactivated, "account is deactivated")
+	}
+
+	return user, nil
+}
+
+func (u *userServiceImpl) Logout(ctx context.Context, accessToken string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "UserService.Logout")
+	defer span.End()
+
+	session, err := u.SessionRepository.FindByToken(ctx, accessToken)
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewUnauthorizedError(web.CodeTokenInvalid, "token is invalid")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	if session.FamilyID == "" {
+		err = u.SessionRepository.DeleteByToken(ctx, accessToken)
+	} else {
+		err = u.SessionRepository.RevokeFamily(ctx, session.FamilyID, time.Now())
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	return nil
+}
+
+func (u *userServiceImpl) Update(ctx context.Context, userUpdateRequest web.UserUpdateRequest) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "UserService.Update")
+	defer span.End()
+
+	user, errResponse := u.findUser(ctx, userUpdateRequest.Username)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if err := u.passwordPolicy.Check(userUpdateRequest.Password); err != nil {
+		return weakPasswordError(err)
+	}
+
+	email := optionalEmail(userUpdateRequest.Email)
+	if u.emailTaken(ctx, email, user.Username) {
+		return web.NewConflictError(web.CodeEmailTaken, "email is already in use")
 	}
 
 	hasPassword, err := helper.HashPassword(userUpdateRequest.Password)
 	if err != nil {
-		return web.NewInternalServerErrorError("failed to hash password")
+		return internalError(ctx, err)
 	}
 
-	err = u.HandlerRepository.UpdateByUsername(userUpdateRequest.Username, &domain.Users{
-		Username: userUpdateRequest.Username,
-		FullName: userUpdateRequest.FullName,
-		Password: hasPassword,
-		Role:     userUpdateRequest.Role,
+	passwordChanged := !helper.CheckPasswordHash(userUpdateRequest.Password, user.Password)
+	err = u.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if userUpdateRequest.Role != user.Role {
+			if err := u.ensureAnotherAdmin(ctx, user); err != nil {
+				return err
+			}
+		}
+
+		err := u.UserRepository.Update(ctx, &domain.Users{
+			Username: userUpdateRequest.Username,
+			FullName: userUpdateRequest.FullName,
+			Password: hasPassword,
+			Role:     userUpdateRequest.Role,
+			Email:    email,
+		})
+		if err != nil || !passwordChanged {
+			return err
+		}
+
+		return u.SessionRepository.RevokeByUsername(ctx, user.Username, time.Now())
+	})
+	if errors.Is(err, errLastAdmin) {
+		return web.NewConflictError(web.CodeLastActiveAdmin, "cannot demote the last active admin")
+	}
+	if errors.Is(err, repository.ErrDuplicate) {
+		return web.NewConflictError(web.CodeEmailTaken, "email is already in use")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	return nil
+}
+
+func (u *userServiceImpl) Delete(ctx context.Context, username string, performedBy string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "UserService.Delete")
+	defer span.End()
+
+	user, errResponse := u.findUser(ctx, username)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if user.Username == performedBy {
+		return web.NewBadRequestError(web.CodeOwnAccount, "cannot delete your own account")
+	}
+
+	activities, err := u.ActivityRepository.CountByPerformer(ctx, user.Username)
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	if activities > 0 {
+		return web.NewConflictError(web.CodeUserHasActivity, "user has recorded activity, deactivate it instead")
+	}
+
+	err = u.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.ensureAnotherAdmin(ctx, user); err != nil {
+			return err
+		}
+
+		if err := u.UserRepository.Delete(ctx, user.Username); err != nil {
+			return err
+		}
+
+		return u.SessionRepository.RevokeByUsername(ctx, user.Username, time.Now())
 	})
+	if errors.Is(err, errLastAdmin) {
+		return web.NewConflictError(web.CodeLastActiveAdmin, "cannot delete the last active admin")
+	}
 	if err != nil {
-		return web.NewInternalServerErrorError(err.Error())
+		return internalError(ctx, err)
 	}
 
 	return nil
 }
 
-func (u *userServiceImpl) Delete(username string) web.ErrorResponse {
-	if !u.CheckAvailable(username) {
-		return web.NewNotFoundError("user not found")
+func (u *userServiceImpl) Deactivate(ctx context.Context, username string, performedBy string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "UserService.Deactivate")
+	defer span.End()
+
+	user, errResponse := u.findUser(ctx, username)
+	if errResponse != nil {
+		return errResponse
 	}
 
-	err := u.HandlerRepository.DeleteByUsername(username, &domain.Users{})
+	if user.Username == performedBy {
+		return web.NewBadRequestError(web.CodeOwnAccount, "cannot deactivate your own account")
+	}
+
+	if !user.Active {
+		return web.NewConflictError(web.CodeUserAlreadyDeactivated, "user is already deactivated")
+	}
+
+	err := u.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.ensureAnotherAdmin(ctx, user); err != nil {
+			return err
+		}
+
+		if err := u.UserRepository.SetActive(ctx, user.Username, false); err != nil {
+			return err
+		}
+
+		return u.SessionRepository.RevokeByUsername(ctx, user.Username, time.Now())
+	})
+	if errors.Is(err, errLastAdmin) {
+		return web.NewConflictError(web.CodeLastActiveAdmin, "cannot deactivate the last active admin")
+	}
 	if err != nil {
-		return web.NewInternalServerErrorError(err.Error())
+		return internalError(ctx, err)
+	}
+
+	return nil
+}
+
+func (u *userServiceImpl) Reactivate(ctx context.Context, username string) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "UserService.Reactivate")
+	defer span.End()
+
+	user, errResponse := u.findUser(ctx, username)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if user.Active {
+		return web.NewConflictError(web.CodeUserAlreadyActive, "user is already active")
+	}
+
+	if err := u.UserRepository.SetActive(ctx, user.Username, true); err != nil {
+		return internalError(ctx, err)
 	}
 
 	return nil
 }
 
-func (u *userServiceImpl) GetAll() ([]domain.Users, web.ErrorResponse) {
-	users := []domain.Users{}
-	err := u.HandlerRepository.GetAll(&users)
+func (u *userServiceImpl) GetAll(ctx context.Context) ([]domain.Users, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "UserService.GetAll")
+	defer span.End()
+
+	users, err := u.UserRepository.FindAll(ctx)
 	if err != nil {
-		return nil, web.NewInternalServerErrorError(err.Error())
+		return nil, internalError(ctx, err)
 	}
 
 	if len(users) == 0 {
-		return nil, web.NewNotFoundError("user not found")
+		return nil, web.NewNotFoundError(web.CodeUserNotFound, "user not found")
 	}
 
 	for i, _ := range users {
@@ -151,21 +678,310 @@ func (u *userServiceImpl) GetAll() ([]domain.Users, web.ErrorResponse) {
 	return users, nil
 }
 
-func (u *userServiceImpl) GetByUsername(username string) (domain.Users, web.ErrorResponse) {
-	user := domain.Users{}
-	err := u.HandlerRepository.GetByUsername(username, &user)
+func (u *userServiceImpl) GetByUsername(ctx context.Context, username string) (domain.Users, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "UserService.GetByUsername")
+	defer span.End()
+
+	user, err := u.UserRepository.FindByUsername(ctx, username)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Users{}, web.NewNotFoundError(web.CodeUserNotFound, "user not found")
+	}
 	if err != nil {
-		return user, web.NewNotFoundError("user not found")
+		return domain.Users{}, internalError(ctx, err)
 	}
 
 	user.Password = "-"
 	return user, nil
 }
 
-func (u *userServiceImpl) CheckAvailable(username string) bool {
-	err := u.HandlerRepository.GetByUsername(username, &domain.Users{})
+func (u *userServiceImpl) CheckAvailable(ctx context.Context, username string) bool {
+	ctx, span := tracing.Start(ctx, "UserService.CheckAvailable")
+	defer span.End()
+
+	_, err := u.UserRepository.FindByUsername(ctx, username)
 	if err != nil {
 		return false
 	}
 	return true
 }
+
+func (u *userServiceImpl) emailTaken(ctx context.Context, email *string, username string) bool {
+	if email == nil {
+		return false
+	}
+
+	user, err := u.UserRepository.FindByEmail(ctx, *email)
+	return err == nil && user.Username != username
+}
+
+func (u *userServiceImpl) GetLoginAttempts(ctx context.Context, username string) ([]domain.LoginAttempts, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "UserService.GetLoginAttempts")
+	defer span.End()
+
+	attempts, err := u.LoginAttemptRepository.FindAll(ctx, username)
+	if err != nil {
+		return nil, internalError(ctx, err)
+	}
+
+	if len(attempts) == 0 {
+		return nil, web.NewNotFoundError(web.CodeLoginAttemptNotFound, "login attempt not found")
+	}
+
+	return attempts, nil
+}
+
+func (u *userServiceImpl) UpdateProfile(ctx context.Context, username string, profileUpdateRequest web.ProfileUpdateRequest) web.ErrorResponse {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateProfile")
+	defer span.End()
+
+	err := u.UserRepository.Update(ctx, &domain.Users{Username: username, FullName: profileUpdateRequest.FullName})
+	if errors.Is(err, repository.ErrNotFound) {
+		return web.NewNotFoundError(web.CodeUserNotFound, "user not found")
+	}
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	return nil
+}
+
+func (u *userServiceImpl) ChangePassword(ctx context.Context, username string, passwordChangeRequest web.PasswordChangeRequest) (domain.TokenPair, web.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "UserService.ChangePassword")
+	defer span.End()
+
+	user, err := u.UserRepository.FindByUsername(ctx, username)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.TokenPair{}, web.NewNotFoundError(web.CodeUserNotFound, "user not found")
+	}
+	if err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	if !helper.CheckPasswordHash(passwordChangeRequest.CurrentPassword, user.Password) {
+		return domain.TokenPair{}, web.NewBadRequestError(web.CodeCurrentPasswordIncorrect, "current password is incorrect")
+	}
+
+	if passwordChangeRequest.NewPassword == passwordChangeRequest.CurrentPassword {
+		return domain.TokenPair{}, web.NewBadRequestError(web.CodePasswordUnchanged, "new password must be different from the current password")
+	}
+
+	if err := u.passwordPolicy.Check(passwordChangeRequest.NewPassword); err != nil {
+		return domain.TokenPair{}, weakPasswordError(err)
+	}
+
+	hashedPassword, err := helper.HashPassword(passwordChangeRequest.NewPassword)
+	if err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	if err := u.UserRepository.Update(ctx, &domain.Users{Username: user.Username, Password: hashedPassword}); err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	if err := u.SessionRepository.RevokeByUsername(ctx, user.Username, time.Now()); err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	familyID, err := randomName()
+	if err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	tokens, err := u.issueTokens(ctx, user, familyID)
+	if err != nil {
+		return domain.TokenPair{}, internalError(ctx, err)
+	}
+
+	return tokens, nil
+}
+
+func (u *userServiceImpl) findUser(ctx context.Context, username string) (domain.Users, web.ErrorResponse) {
+	user, err := u.UserRepository.FindByUsername(ctx, username)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Users{}, web.NewNotFoundError(web.CodeUserNotFound, "user not found")
+	}
+	if err != nil {
+		return domain.Users{}, internalError(ctx, err)
+	}
+
+	return user, nil
+}
+
+func (u *userServiceImpl) ensureAnotherAdmin(ctx context.Context, user domain.Users) error {
+	if user.Role != "admin" || !user.Active {
+		return nil
+	}
+
+	admins, err := u.UserRepository.LockActiveByRole(ctx, "admin")
+	if err != nil {
+		return err
+	}
+
+	if admins <= 1 {
+		return errLastAdmin
+	}
+
+	return nil
+}
+
+func (u *userServiceImpl) issueTokens(ctx context.Context, user domain.Users, familyID string) (domain.TokenPair, error) {
+	tokenID, err := randomName()
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	refreshToken, err := randomName()
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	csrfToken, err := randomName()
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	now := time.Now()
+	claims := &domain.JwtCustomClaims{
+		Username: user.Username,
+		Role:     user.Role,
+		StandardClaims: jwt.StandardClaims{
+			Id:        tokenID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(u.accessTokenTTL).Unix(),
+		},
+	}
+
+	tokenString, err := u.keys.Sign(claims)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	session := domain.Sessions{
+		Username:         user.Username,
+		Token:            tokenString,
+		ExpiresAt:        now.Add(u.accessTokenTTL),
+		FamilyID:         familyID,
+		RefreshTokenHash: hashToken(refreshToken),
+		RefreshExpiresAt: now.Add(u.refreshTokenTTL),
+	}
+
+	if err := u.SessionRepository.Create(ctx, &session); err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	return domain.TokenPair{
+		AccessToken:      tokenString,
+		TokenType:        "Bearer",
+		ExpiresAt:        session.ExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.RefreshExpiresAt,
+		CSRFToken:        csrfToken,
+	}, nil
+}
+
+func (u *userServiceImpl) revokeFamily(ctx context.Context, session domain.Sessions, now time.Time) web.ErrorResponse {
+	if err := u.SessionRepository.RevokeFamily(ctx, session.FamilyID, now); err != nil {
+		return internalError(ctx, err)
+	}
+
+	logging.FromContext(ctx).WarnContext(ctx, "refresh token reuse detected, session family revoked",
+		"session_username", session.Username, "family_id", session.FamilyID)
+	return web.NewUnauthorizedError(web.CodeRefreshTokenReused, "refresh token reuse detected")
+}
+
+func (u *userServiceImpl) recordFailure(ctx context.Context, username string, now time.Time) web.ErrorResponse {
+	if u.maxFailures <= 0 {
+		return nil
+	}
+
+	failures, err := u.UserRepository.IncrementFailedLogins(ctx, username)
+	if err != nil {
+		return internalError(ctx, err)
+	}
+
+	if failures < u.maxFailures {
+		return nil
+	}
+
+	lockedUntil := now.Add(u.lockoutDuration)
+	if err := u.UserRepository.SetLoginState(ctx, username, 0, &lockedUntil); err != nil {
+		return internalError(ctx, err)
+	}
+
+	logging.FromContext(ctx).WarnContext(ctx, "account locked", "locked_username", username, "locked_until", lockedUntil)
+	return nil
+}
+
+func (u *userServiceImpl) mfaChallenge(user domain.Users, now time.Time) (domain.MFAChallenge, error) {
+	tokenID, err := randomName()
+	if err != nil {
+		return domain.MFAChallenge{}, err
+	}
+
+	expiresAt := now.Add(u.mfa.ChallengeTTL)
+	mfaToken, err := u.keys.Sign(&jwt.StandardClaims{
+		Id:        tokenID,
+		Subject:   user.Username,
+		Audience:  domain.MFAAudience,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return domain.MFAChallenge{}, err
+	}
+
+	return domain.MFAChallenge{MFAToken: mfaToken, ExpiresAt: expiresAt, EnrollmentRequired: !user.TOTPEnabled}, nil
+}
+
+func (u *userServiceImpl) mfaUser(ctx context.Context, mfaToken string) (domain.Users, web.ErrorResponse) {
+	claims := &jwt.StandardClaims{}
+	token, err := u.keys.Parse(mfaToken, claims)
+	if err != nil || !token.Valid || !claims.VerifyAudience(domain.MFAAudience, true) {
+		return domain.Users{}, web.NewUnauthorizedError(web.CodeMFATokenInvalid, "invalid mfa token")
+	}
+
+	user, err := u.UserRepository.FindByUsername(ctx, claims.Subject)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domain.Users{}, web.NewUnauthorizedError(web.CodeMFATokenInvalid, "invalid mfa token")
+	}
+	if err != nil {
+		return domain.Users{}, internalError(ctx, err)
+	}
+
+	return user, nil
+}
+
+func (u *userServiceImpl) recordAttempt(ctx context.Context, username string, ipAddress string, reason string) {
+	err := u.LoginAttemptRepository.Create(ctx, &domain.LoginAttempts{
+		Username:  username,
+		IPAddress: ipAddress,
+		Success:   reason == domain.LoginSuccess,
+		Reason:    reason,
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to record login attempt", "error", err)
+	}
+}
+
+func optionalEmail(email string) *string {
+	if email == "" {
+		return nil
+	}
+
+	email = strings.ToLower(email)
+	return &email
+}
+
+func equalEmail(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,224 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"inventory-management-system/config"
+	"log/slog"
+	"os"
+)
+
+const minRSABits = 2048
+
+var (
+	ErrUnknownKey        = errors.New("unknown signing key")
+	ErrUnsupportedKey    = errors.New("unsupported key type")
+	ErrAlgorithmMismatch = errors.New("token algorithm does not match key")
+)
+
+type KeySet interface {
+	KeyID() string
+	Sign(claims jwt.Claims) (string, error)
+	Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error)
+	JWKS() JWKS
+}
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+}
+
+type keySetImpl struct {
+	keyID   string
+	method  jwt.SigningMethod
+	signer  crypto.Signer
+	keys    map[string]verificationKey
+	ordered []string
+}
+
+func New(signer crypto.Signer, verificationKeys ...crypto.PublicKey) (KeySet, error) {
+	method, err := methodFor(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	keyID, err := Thumbprint(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	keySet := &keySetImpl{keyID: keyID, method: method, signer: signer, keys: map[string]verificationKey{}}
+	for _, key := range append([]crypto.PublicKey{signer.Public()}, verificationKeys...) {
+		if err := keySet.add(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return keySet, nil
+}
+
+func Generate() (KeySet, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(privateKey)
+}
+
+func Load(cfg config.Signing) (KeySet, error) {
+	if cfg.PrivateKeyPath == "" {
+		slog.Warn("no signing key configured, using an ephemeral key; tokens will not survive a restart")
+		return Generate()
+	}
+
+	signer, err := readPrivateKey(cfg.PrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationKeys := make([]crypto.PublicKey, 0, len(cfg.VerificationKeyPaths))
+	for _, path := range cfg.VerificationKeyPaths {
+		key, err := readPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		verificationKeys = append(verificationKeys, key)
+	}
+
+	return New(signer, verificationKeys...)
+}
+
+func (k *keySetImpl) KeyID() string {
+	return k.keyID
+}
+
+func (k *keySetImpl) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.method, claims)
+	token.Header["kid"] = k.keyID
+
+	return token.SignedString(k.signer)
+}
+
+func (k *keySetImpl) Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		keyID, _ := token.Header["kid"].(string)
+		key, ok := k.keys[keyID]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, ErrAlgorithmMismatch
+		}
+
+		return key.key, nil
+	})
+}
+
+func (k *keySetImpl) add(key crypto.PublicKey) error {
+	method, err := methodFor(key)
+	if err != nil {
+		return err
+	}
+
+	keyID, err := Thumbprint(key)
+	if err != nil {
+		return err
+	}
+
+	if _, exists := k.keys[keyID]; !exists {
+		k.keys[keyID] = verificationKey{method: method, key: key}
+		k.ordered = append(k.ordered, keyID)
+	}
+
+	return nil
+}
+
+func methodFor(key crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("rsa key must be at least %d bits", minRSABits)
+		}
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+func readPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key %s: %w", path, err)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("private key %s: %w", path, ErrUnsupportedKey)
+	}
+}
+
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse certificate %s: %w", path, err)
+		}
+		return certificate.PublicKey, nil
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse public key %s: %w", path, err)
+		}
+		return key, nil
+	default:
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse public key %s: %w", path, err)
+		}
+		return key, nil
+	}
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("%s does not contain a PEM block", path)
+	}
+
+	return block, nil
+}
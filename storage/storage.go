@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-management-system/config"
+	"inventory-management-system/tracing"
+	"io"
+	"net/http"
+)
+
+var ErrNotFound = errors.New("storage: object not found")
+
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func New(storageConfig config.Storage) (Storage, error) {
+	switch storageConfig.Driver {
+	case "local":
+		return NewLocalStorage(storageConfig.LocalPath)
+	case "s3":
+		return NewS3Storage(storageConfig.S3Endpoint, storageConfig.S3Region, storageConfig.S3Bucket,
+			storageConfig.S3AccessKey, storageConfig.S3SecretKey, &http.Client{Transport: tracing.NewTransport(http.DefaultTransport)})
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", storageConfig.Driver)
+	}
+}
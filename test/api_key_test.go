@@ -0,0 +1,118 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/model/domain"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var _ = Describe("API keys", func() {
+	var server *testServer
+
+	createKey := func(body map[string]any) domain.APIKeyCreated {
+		res := server.do(http.MethodPost, "/api/v1/api-keys", body)
+		Expect(res.Code).To(Equal(http.StatusCreated))
+		return decode[domain.APIKeyCreated](res)
+	}
+
+	withKey := func(method string, path string, body any, key string) response {
+		cookie := server.cookie
+		server.cookie = nil
+		defer func() { server.cookie = cookie }()
+
+		res, _ := server.doWithHeaders(method, path, body, http.Header{"X-API-Key": {key}})
+		return res
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "ram"}).Code).To(Equal(http.StatusCreated))
+	})
+
+	It("accepts the access token as a bearer token", func() {
+		token := server.cookie.Value
+		server.cookie = nil
+
+		res, _ := server.doWithHeaders(http.MethodGet, "/api/v1/users", nil, http.Header{"Authorization": {"Bearer " + token}})
+		Expect(res.Code).To(Equal(http.StatusOK))
+	})
+
+	It("issues a key that is only shown once and stored hashed", func() {
+		created := createKey(map[string]any{"name": "scanner", "scopes": []string{"categories:read"}})
+		Expect(created.Key).To(HavePrefix(domain.APIKeyPrefix))
+		Expect(created.Key).To(HavePrefix(created.Prefix))
+		Expect(created.CreatedBy).To(Equal("administrator"))
+
+		var stored domain.APIKeys
+		Expect(server.connection.First(&stored, created.ID).Error).To(Succeed())
+		Expect(stored.KeyHash).NotTo(BeEmpty())
+		Expect(stored.KeyHash).NotTo(ContainSubstring(created.Key))
+
+		keys := decode[[]map[string]any](server.do(http.MethodGet, "/api/v1/api-keys", nil))
+		Expect(keys).To(HaveLen(1))
+		Expect(keys[0]).NotTo(HaveKey("key"))
+		Expect(keys[0]).NotTo(HaveKey("key_hash"))
+	})
+
+	It("authenticates with the key header or a bearer token and records its use", func() {
+		created := createKey(map[string]any{"name": "scanner", "scopes": []string{"categories:read"}})
+
+		Expect(withKey(http.MethodGet, "/api/v1/category", nil, created.Key).Code).To(Equal(http.StatusOK))
+
+		server.cookie = nil
+		res, _ := server.doWithHeaders(http.MethodGet, "/api/v1/category", nil, http.Header{"Authorization": {"Bearer " + created.Key}})
+		Expect(res.Code).To(Equal(http.StatusOK))
+
+		var stored domain.APIKeys
+		Expect(server.connection.First(&stored, created.ID).Error).To(Succeed())
+		Expect(stored.LastUsedAt).NotTo(BeNil())
+	})
+
+	It("enforces the scopes of the key", func() {
+		created := createKey(map[string]any{"name": "scanner", "scopes": []string{"categories:read"}})
+
+		res := withKey(http.MethodPost, "/api/v1/category", map[string]any{"name": "cpu"}, created.Key)
+		Expect(res.Code).To(Equal(http.StatusForbidden))
+		Expect(res.Message).To(Equal("api key is missing scope categories:write"))
+
+		Expect(withKey(http.MethodGet, "/api/v1/items", nil, created.Key).Code).To(Equal(http.StatusForbidden))
+		Expect(withKey(http.MethodGet, "/api/v1/users", nil, created.Key).Code).To(Equal(http.StatusUnauthorized))
+		Expect(withKey(http.MethodGet, "/api/v1/api-keys", nil, created.Key).Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects unknown scopes and past expiries", func() {
+		res := server.do(http.MethodPost, "/api/v1/api-keys", map[string]any{"name": "scanner", "scopes": []string{"users:write"}})
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+
+		res = server.do(http.MethodPost, "/api/v1/api-keys", map[string]any{
+			"name": "scanner", "scopes": []string{"*"}, "expires_at": time.Now().Add(-time.Hour),
+		})
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("rejects revoked, expired and unknown keys", func() {
+		created := createKey(map[string]any{"name": "scanner", "scopes": []string{"*"}})
+		Expect(server.do(http.MethodDelete, "/api/v1/api-keys/"+strconv.Itoa(created.ID), nil).Code).To(Equal(http.StatusOK))
+		Expect(server.do(http.MethodDelete, "/api/v1/api-keys/"+strconv.Itoa(created.ID), nil).Code).To(Equal(http.StatusConflict))
+
+		res := withKey(http.MethodGet, "/api/v1/category", nil, created.Key)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("api key is revoked"))
+
+		expiring := createKey(map[string]any{"name": "batch", "scopes": []string{"*"}, "expires_at": time.Now().Add(time.Hour)})
+		Expect(server.connection.Model(&domain.APIKeys{}).Where("id = ?", expiring.ID).
+			Update("expires_at", time.Now().Add(-time.Minute)).Error).To(Succeed())
+
+		res = withKey(http.MethodGet, "/api/v1/category", nil, expiring.Key)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("api key is expired"))
+
+		res = withKey(http.MethodGet, "/api/v1/category", nil, domain.APIKeyPrefix+"unknown")
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("api key is invalid"))
+	})
+})
@@ -0,0 +1,108 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/model/domain"
+	"net/http"
+	"time"
+)
+
+var _ = Describe("Assignments", func() {
+	var server *testServer
+
+	quantity := func(itemID string) int {
+		res := server.do(http.MethodGet, "/api/v1/items/"+itemID, nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		return decode[domain.Items](res).Quantity
+	}
+
+	checkOut := func(assignee string, amount int, dueDate time.Time) response {
+		return server.do(http.MethodPost, "/api/v1/assignments/checkout", map[string]any{
+			"item_id": 1, "assignee": assignee, "quantity": amount, "due_date": dueDate,
+		})
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "cable"}).Code).To(Equal(http.StatusCreated))
+		Expect(server.do(http.MethodPost, "/api/v1/items", map[string]any{
+			"name": "HDMI cable", "category_id": 1, "quantity": 10, "price": 5, "specification": "2m",
+		}).Code).To(Equal(http.StatusCreated))
+		Expect(server.do(http.MethodPost, "/api/v1/users", map[string]any{
+			"full_name": "Jane Doe", "username": "janedoe", "password": "Jane-pass-7", "role": "user",
+		}).Code).To(Equal(http.StatusCreated))
+	})
+
+	It("takes stock out on check-out and returns it on check-in", func() {
+		res := checkOut("janedoe", 4, time.Now().Add(24*time.Hour))
+		Expect(res.Code).To(Equal(http.StatusCreated))
+		assignment := decode[domain.Assignments](res)
+		Expect(assignment.Quantity).To(Equal(4))
+		Expect(assignment.CheckedOutBy).To(Equal("administrator"))
+		Expect(quantity("1")).To(Equal(6))
+
+		holders := decode[[]domain.Assignments](server.do(http.MethodGet, "/api/v1/assignments/holders/janedoe", nil))
+		Expect(holders).To(HaveLen(1))
+
+		res = server.do(http.MethodPost, "/api/v1/assignments/1/checkin", map[string]any{"note": "returned intact"})
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(quantity("1")).To(Equal(10))
+		Expect(server.do(http.MethodGet, "/api/v1/assignments", nil).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("checks an assignment in only once", func() {
+		Expect(checkOut("janedoe", 3, time.Now().Add(time.Hour)).Code).To(Equal(http.StatusCreated))
+		Expect(server.do(http.MethodPost, "/api/v1/assignments/1/checkin", nil).Code).To(Equal(http.StatusOK))
+
+		res := server.do(http.MethodPost, "/api/v1/assignments/1/checkin", nil)
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Problem.Code).To(Equal("ASSIGNMENT_ALREADY_CHECKED_IN"))
+		Expect(quantity("1")).To(Equal(10))
+
+		Expect(server.do(http.MethodPost, "/api/v1/assignments/42/checkin", nil).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("refuses to check out more than is in stock", func() {
+		Expect(checkOut("janedoe", 8, time.Now().Add(time.Hour)).Code).To(Equal(http.StatusCreated))
+
+		res := checkOut("janedoe", 3, time.Now().Add(time.Hour))
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Message).To(Equal("insufficient stock"))
+		Expect(quantity("1")).To(Equal(2))
+	})
+
+	It("validates the assignee and due date", func() {
+		res := checkOut("nobody", 1, time.Now().Add(time.Hour))
+		Expect(res.Code).To(Equal(http.StatusNotFound))
+		Expect(res.Message).To(Equal("assignee not found"))
+
+		res = checkOut("janedoe", 1, time.Now().Add(-time.Hour))
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("due date must be in the future"))
+		Expect(quantity("1")).To(Equal(10))
+	})
+
+	It("lists assignments past their due date", func() {
+		Expect(checkOut("janedoe", 1, time.Now().Add(time.Hour)).Code).To(Equal(http.StatusCreated))
+		Expect(checkOut("administrator", 2, time.Now().Add(48*time.Hour)).Code).To(Equal(http.StatusCreated))
+		Expect(server.do(http.MethodGet, "/api/v1/assignments/overdue", nil).Code).To(Equal(http.StatusNotFound))
+
+		Expect(server.connection.Model(&domain.Assignments{}).Where("id = ?", 1).
+			Update("due_date", time.Now().Add(-time.Hour)).Error).To(Succeed())
+
+		res := server.do(http.MethodGet, "/api/v1/assignments/overdue", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		overdue := decode[[]domain.Assignments](res)
+		Expect(overdue).To(HaveLen(1))
+		Expect(overdue[0].Assignee).To(Equal("janedoe"))
+
+		mine := decode[[]domain.Assignments](server.do(http.MethodGet, "/api/v1/assignments/mine", nil))
+		Expect(mine).To(HaveLen(1))
+		Expect(mine[0].Quantity).To(Equal(2))
+
+		Expect(server.do(http.MethodPost, "/api/v1/assignments/1/checkin", nil).Code).To(Equal(http.StatusOK))
+		Expect(server.do(http.MethodGet, "/api/v1/assignments/overdue", nil).Code).To(Equal(http.StatusNotFound))
+	})
+})
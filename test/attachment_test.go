@@ -0,0 +1,141 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Attachments", func() {
+	var server *testServer
+
+	upload := func(fileName string, content []byte, kind string) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		if kind != "" {
+			Expect(writer.WriteField("kind", kind)).To(Succeed())
+		}
+		if fileName != "" {
+			part, err := writer.CreateFormFile("file", fileName)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = part.Write(content)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(writer.Close()).To(Succeed())
+
+		return server.fetch(http.MethodPost, "/api/v1/items/1/attachments", body, writer.FormDataContentType())
+	}
+
+	uploaded := func(recorder *httptest.ResponseRecorder) domain.Attachments {
+		Expect(recorder.Code).To(Equal(http.StatusCreated), recorder.Body.String())
+		var res response
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &res)).To(Succeed())
+		return decode[domain.Attachments](res)
+	}
+
+	problem := func(recorder *httptest.ResponseRecorder) web.Problem {
+		var problem web.Problem
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &problem)).To(Succeed(), recorder.Body.String())
+		return problem
+	}
+
+	picture := func(width int, height int) []byte {
+		buf := bytes.Buffer{}
+		Expect(png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))).To(Succeed())
+		return buf.Bytes()
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "monitor"}).Code).To(Equal(http.StatusCreated))
+		Expect(server.do(http.MethodPost, "/api/v1/items", map[string]any{
+			"name": "Monitor 24", "category_id": 1, "quantity": 1, "price": 150, "specification": "IPS",
+		}).Code).To(Equal(http.StatusCreated))
+	})
+
+	It("stores an image together with a thumbnail", func() {
+		content := picture(600, 300)
+		attachment := uploaded(upload("front.png", content, "photo"))
+		Expect(attachment.ContentType).To(Equal("image/png"))
+		Expect(attachment.Size).To(Equal(int64(len(content))))
+		Expect(attachment.HasThumbnail).To(BeTrue())
+
+		recorder := server.fetch(http.MethodGet, "/api/v1/attachments/1", nil, "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("image/png"))
+		Expect(recorder.Body.Bytes()).To(Equal(content))
+
+		recorder = server.fetch(http.MethodGet, "/api/v1/attachments/1/thumbnail", nil, "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("image/jpeg"))
+		thumbnail, err := jpeg.Decode(recorder.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(thumbnail.Bounds().Dx()).To(Equal(256))
+		Expect(thumbnail.Bounds().Dy()).To(Equal(128))
+
+		Expect(decode[[]domain.Attachments](server.do(http.MethodGet, "/api/v1/items/1/attachments", nil))).To(HaveLen(1))
+		Expect(server.do(http.MethodDelete, "/api/v1/attachments/1", nil).Code).To(Equal(http.StatusOK))
+		Expect(server.fetch(http.MethodGet, "/api/v1/attachments/1/thumbnail", nil, "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("keeps small images at their size and documents without a thumbnail", func() {
+		Expect(uploaded(upload("icon.png", picture(32, 16), "photo")).HasThumbnail).To(BeTrue())
+		thumbnail, err := jpeg.Decode(server.fetch(http.MethodGet, "/api/v1/attachments/1/thumbnail", nil, "").Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(thumbnail.Bounds().Size()).To(Equal(image.Pt(32, 16)))
+
+		attachment := uploaded(upload("datasheet.pdf", []byte("%PDF-1.4\n%datasheet\n"), "datasheet"))
+		Expect(attachment.ContentType).To(Equal("application/pdf"))
+		Expect(attachment.HasThumbnail).To(BeFalse())
+
+		recorder := server.fetch(http.MethodGet, "/api/v1/attachments/2/thumbnail", nil, "")
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		Expect(problem(recorder).Code).To(Equal("THUMBNAIL_NOT_FOUND"))
+	})
+
+	It("rejects file types that are not allowed by their content", func() {
+		recorder := upload("invoice.pdf", []byte("<html><body>not a pdf</body></html>"), "invoice")
+		Expect(recorder.Code).To(Equal(http.StatusUnsupportedMediaType))
+		Expect(problem(recorder).Code).To(Equal("FILE_TYPE_NOT_ALLOWED"))
+		Expect(problem(recorder).Detail).To(Equal("file type text/html is not allowed"))
+
+		recorder = upload("archive.png", []byte("PK\x03\x04\x14\x00\x00\x00\x08\x00"), "photo")
+		Expect(recorder.Code).To(Equal(http.StatusUnsupportedMediaType))
+		Expect(problem(recorder).Detail).To(Equal("file type application/zip is not allowed"))
+
+		Expect(server.do(http.MethodGet, "/api/v1/items/1/attachments", nil).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("enforces the upload size limit", func() {
+		recorder := upload("notes.txt", bytes.Repeat([]byte("a"), maxUploadSize+1), "other")
+		Expect(recorder.Code).To(Equal(http.StatusRequestEntityTooLarge))
+		Expect(problem(recorder).Code).To(Equal("FILE_TOO_LARGE"))
+		Expect(problem(recorder).Detail).To(Equal("file exceeds the 1048576 bytes limit"))
+
+		recorder = upload("notes.txt", bytes.Repeat([]byte("a"), 3*maxUploadSize), "other")
+		Expect(recorder.Code).To(Equal(http.StatusRequestEntityTooLarge))
+		Expect(problem(recorder).Code).To(Equal("FILE_TOO_LARGE"))
+
+		Expect(uploaded(upload("notes.txt", bytes.Repeat([]byte("a"), maxUploadSize), "other")).Size).To(Equal(int64(maxUploadSize)))
+	})
+
+	It("requires a file and a known kind", func() {
+		recorder := upload("", nil, "photo")
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(problem(recorder).Code).To(Equal("FILE_REQUIRED"))
+
+		recorder = upload("notes.txt", []byte("hello"), "selfie")
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(problem(recorder).Errors).To(ConsistOf(HaveField("Field", "kind")))
+	})
+})
@@ -0,0 +1,121 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/model/domain"
+	"net/http"
+	"net/url"
+)
+
+var _ = Describe("Category attributes", func() {
+	var server *testServer
+
+	addAttribute := func(attribute map[string]any) response {
+		return server.do(http.MethodPost, "/api/v1/category/1/attributes", attribute)
+	}
+
+	addItem := func(name string, attributes map[string]any) response {
+		return server.do(http.MethodPost, "/api/v1/items", map[string]any{
+			"name": name, "category_id": 1, "quantity": 1, "price": 900, "specification": name, "attributes": attributes,
+		})
+	}
+
+	names := func(filters ...string) []string {
+		res := server.do(http.MethodGet, "/api/v1/items?"+url.Values{"attr": filters}.Encode(), nil)
+		if res.Code == http.StatusNotFound {
+			return nil
+		}
+		Expect(res.Code).To(Equal(http.StatusOK))
+
+		var names []string
+		for _, item := range decode[[]domain.Items](res) {
+			names = append(names, item.Name)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "laptop"}).Code).To(Equal(http.StatusCreated))
+		Expect(addAttribute(map[string]any{"name": "ram_gb", "type": "integer", "unit": "GB", "required": true}).Code).To(Equal(http.StatusCreated))
+		Expect(addAttribute(map[string]any{"name": "screen", "type": "number"}).Code).To(Equal(http.StatusCreated))
+		Expect(addAttribute(map[string]any{"name": "touch", "type": "boolean"}).Code).To(Equal(http.StatusCreated))
+		Expect(addAttribute(map[string]any{"name": "os", "type": "enum", "options": []string{"linux", "windows"}}).Code).To(Equal(http.StatusCreated))
+		Expect(addAttribute(map[string]any{"name": "model", "type": "string"}).Code).To(Equal(http.StatusCreated))
+	})
+
+	It("stores typed values and returns them with the item", func() {
+		Expect(addItem("T14", map[string]any{"ram_gb": 16, "screen": 14.0, "touch": false, "os": "linux", "model": "T14 gen 4"}).Code).To(Equal(http.StatusCreated))
+
+		item := decode[domain.Items](server.do(http.MethodGet, "/api/v1/items/1", nil))
+		Expect(item.Attributes).To(Equal(map[string]any{"ram_gb": 16.0, "screen": 14.0, "touch": false, "os": "linux", "model": "T14 gen 4"}))
+
+		attributes := decode[[]domain.CategoryAttributes](server.do(http.MethodGet, "/api/v1/category/1/attributes", nil))
+		Expect(attributes).To(HaveLen(5))
+	})
+
+	It("validates values against the category schema", func() {
+		for _, invalid := range []struct {
+			attributes map[string]any
+			message    string
+		}{
+			{map[string]any{"screen": 14.0}, "attribute ram_gb is required"},
+			{map[string]any{"ram_gb": 16.5}, "attribute ram_gb must be an integer"},
+			{map[string]any{"ram_gb": "16"}, "attribute ram_gb must be a number"},
+			{map[string]any{"ram_gb": 16, "touch": "yes"}, "attribute touch must be a boolean"},
+			{map[string]any{"ram_gb": 16, "os": "macos"}, "attribute os must be one of linux, windows"},
+			{map[string]any{"ram_gb": 16, "model": 14}, "attribute model must be a string"},
+			{map[string]any{"ram_gb": 16, "battery": "removable"}, "unknown attribute battery"},
+		} {
+			res := addItem("Invalid", invalid.attributes)
+			Expect(res.Code).To(Equal(http.StatusBadRequest))
+			Expect(res.Message).To(Equal(invalid.message))
+		}
+	})
+
+	It("rejects invalid and duplicate attribute definitions", func() {
+		res := addAttribute(map[string]any{"name": "RAM", "type": "integer"})
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Problem.Code).To(Equal("ATTRIBUTE_NAME_INVALID"))
+
+		res = addAttribute(map[string]any{"name": "ram_gb", "type": "integer"})
+		Expect(res.Code).To(Equal(http.StatusConflict))
+
+		res = addAttribute(map[string]any{"name": "color", "type": "enum"})
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Problem.Code).To(Equal("VALIDATION_FAILED"))
+	})
+
+	It("filters items by attribute values", func() {
+		Expect(addItem("T14", map[string]any{"ram_gb": 16, "screen": 14.0, "touch": false, "os": "linux"}).Code).To(Equal(http.StatusCreated))
+		Expect(addItem("X1", map[string]any{"ram_gb": 32, "screen": 14.0, "touch": true, "os": "windows"}).Code).To(Equal(http.StatusCreated))
+		Expect(addItem("E14", map[string]any{"ram_gb": 8, "screen": 15.6, "os": "windows"}).Code).To(Equal(http.StatusCreated))
+
+		Expect(names("ram_gb>=16")).To(ConsistOf("T14", "X1"))
+		Expect(names("ram_gb>16")).To(ConsistOf("X1"))
+		Expect(names("ram_gb<=16")).To(ConsistOf("T14", "E14"))
+		Expect(names("ram_gb<16")).To(ConsistOf("E14"))
+		Expect(names("ram_gb=32")).To(ConsistOf("X1"))
+		Expect(names("ram_gb!=32")).To(ConsistOf("T14", "E14"))
+		Expect(names("screen=14")).To(ConsistOf("T14", "X1"))
+		Expect(names("touch=true")).To(ConsistOf("X1"))
+		Expect(names("os=windows", "ram_gb>=16")).To(ConsistOf("X1"))
+		Expect(names("os=macos")).To(BeEmpty())
+	})
+
+	It("rejects malformed filters", func() {
+		for filter, message := range map[string]string{
+			"ram_gb":         `invalid attribute filter "ram_gb"`,
+			"=16":            `invalid attribute filter "=16"`,
+			"RAM>=16":        `invalid attribute name "RAM"`,
+			"ram_gb>=":       `attribute filter "ram_gb>=" has no value`,
+			"ram_gb>=plenty": `attribute filter "ram_gb>=plenty" needs a numeric value`,
+		} {
+			res := server.do(http.MethodGet, "/api/v1/items?"+url.Values{"attr": {filter}}.Encode(), nil)
+			Expect(res.Code).To(Equal(http.StatusBadRequest))
+			Expect(res.Message).To(Equal(message))
+		}
+	})
+})
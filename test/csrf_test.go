@@ -0,0 +1,88 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/middleware"
+	"inventory-management-system/model/domain"
+	"net/http"
+)
+
+var _ = Describe("Cookies and CSRF", func() {
+	var server *testServer
+	var tokens domain.TokenPair
+	var csrfToken string
+
+	category := map[string]any{"name": "ram"}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		res := server.login("administrator", adminPassword)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		tokens = server.tokens()
+		csrfToken = decode[domain.TokenPair](res).CSRFToken
+	})
+
+	It("sets hardened cookie attributes on login", func() {
+		Expect(server.cookie.HttpOnly).To(BeTrue())
+		Expect(server.cookie.Secure).To(BeTrue())
+		Expect(server.cookie.SameSite).To(Equal(http.SameSiteStrictMode))
+		Expect(server.cookie.Path).To(Equal("/"))
+
+		Expect(server.csrf.Value).To(Equal(csrfToken))
+		Expect(server.csrf.HttpOnly).To(BeFalse())
+		Expect(server.csrf.Secure).To(BeTrue())
+	})
+
+	It("accepts a cookie authenticated request that echoes the csrf token", func() {
+		Expect(server.do(http.MethodPost, "/api/v1/category", category).Code).To(Equal(http.StatusCreated))
+	})
+
+	It("rejects a cross-site post that carries only the session cookie", func() {
+		server.csrf = nil
+
+		res := server.do(http.MethodPost, "/api/v1/category", category)
+		Expect(res.Code).To(Equal(http.StatusForbidden))
+		Expect(res.Message).To(Equal("csrf token is invalid"))
+	})
+
+	It("rejects a csrf header that does not match the cookie", func() {
+		res, _ := server.doWithHeaders(http.MethodPost, "/api/v1/category", category, http.Header{middleware.CSRFHeader: {"forged"}})
+		Expect(res.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("does not require the csrf token for safe methods", func() {
+		server.csrf = nil
+		Expect(server.do(http.MethodGet, "/api/v1/users", nil).Code).To(Equal(http.StatusOK))
+	})
+
+	It("exempts bearer token callers", func() {
+		server.cookie = nil
+		server.csrf = nil
+
+		res, _ := server.doWithHeaders(http.MethodPost, "/api/v1/category", category, http.Header{"Authorization": {"Bearer " + tokens.AccessToken}})
+		Expect(res.Code).To(Equal(http.StatusCreated))
+	})
+
+	It("exempts api key callers", func() {
+		res := server.do(http.MethodPost, "/api/v1/api-keys", map[string]any{"name": "scanner", "scopes": []string{"categories:write"}})
+		Expect(res.Code).To(Equal(http.StatusCreated))
+		key := decode[domain.APIKeyCreated](res)
+
+		server.cookie = nil
+		server.csrf = nil
+		res, _ = server.doWithHeaders(http.MethodPost, "/api/v1/category", category, http.Header{"X-API-Key": {key.Key}})
+		Expect(res.Code).To(Equal(http.StatusCreated))
+	})
+
+	It("requires the csrf token when refreshing from the cookie", func() {
+		server.cookie = nil
+		csrf := server.csrf
+		server.csrf = nil
+
+		Expect(server.do(http.MethodPost, "/api/v1/token/refresh", nil).Code).To(Equal(http.StatusForbidden))
+
+		server.csrf = csrf
+		Expect(server.do(http.MethodPost, "/api/v1/token/refresh", nil).Code).To(Equal(http.StatusOK))
+	})
+})
@@ -0,0 +1,103 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/model/web"
+	"net/http"
+)
+
+var invalidUser = map[string]any{
+	"full_name": "Jane Doe",
+	"username":  "jd",
+	"role":      "owner",
+	"email":     "not-an-email",
+}
+
+var _ = Describe("Error responses", func() {
+	var server *testServer
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+	})
+
+	It("answers with problem details and a stable code", func() {
+		res := server.do(http.MethodGet, "/api/v1/items/999", nil)
+		Expect(res.Code).To(Equal(http.StatusNotFound))
+		Expect(res.Problem).To(Equal(web.Problem{
+			Type:     "about:blank",
+			Title:    "Not Found",
+			Status:   http.StatusNotFound,
+			Detail:   "item not found",
+			Instance: "/api/v1/items/999",
+			Code:     "ITEM_NOT_FOUND",
+		}))
+	})
+
+	It("lists every invalid field", func() {
+		res := server.do(http.MethodPost, "/api/v1/users", invalidUser)
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Problem.Code).To(Equal("VALIDATION_FAILED"))
+		Expect(res.Problem.Errors).To(ConsistOf(
+			web.FieldError{Field: "username", Code: "min", Message: "username must be at least 5 characters in length"},
+			web.FieldError{Field: "password", Code: "required", Message: "password is a required field"},
+			web.FieldError{Field: "role", Code: "oneof", Message: "role must be one of [admin user]"},
+			web.FieldError{Field: "email", Code: "email", Message: "email must be a valid email address"},
+		))
+	})
+
+	It("names nested fields by their json path", func() {
+		res := server.do(http.MethodPost, "/api/v1/api-keys", map[string]any{"name": "scanner", "scopes": []string{""}})
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Problem.Errors).To(ConsistOf(web.FieldError{Field: "scopes[0]", Code: "required", Message: "scopes[0] is a required field"}))
+	})
+
+	It("translates messages into the preferred language", func() {
+		indonesian := http.Header{"Accept-Language": {"fr-FR, id-ID;q=0.9, en;q=0.8"}}
+
+		res, headers := server.doWithHeaders(http.MethodPost, "/api/v1/users", invalidUser, indonesian)
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(headers.Get("Content-Language")).To(Equal("id"))
+		Expect(res.Message).To(Equal("validasi gagal"))
+		Expect(res.Problem.Errors).To(ContainElement(web.FieldError{Field: "password", Code: "required", Message: "password wajib diisi"}))
+
+		res, _ = server.doWithHeaders(http.MethodGet, "/api/v1/items/999", nil, indonesian)
+		Expect(res.Problem.Code).To(Equal("ITEM_NOT_FOUND"))
+		Expect(res.Message).To(Equal("barang tidak ditemukan"))
+
+		res, _ = server.doWithHeaders(http.MethodPost, "/api/v1/api-keys", map[string]any{"name": "scanner", "scopes": []string{"items:fly"}}, indonesian)
+		Expect(res.Message).To(Equal("scope items:fly tidak dikenal"))
+
+		res, _ = server.doWithHeaders(http.MethodPost, "/api/v1/me/password", map[string]any{"current_password": adminPassword, "new_password": "short"}, http.Header{"Accept-Language": {"id"}})
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("password minimal 8 karakter"))
+	})
+
+	It("falls back to English for other languages", func() {
+		res, headers := server.doWithHeaders(http.MethodGet, "/api/v1/items/999", nil, http.Header{"Accept-Language": {"fr, id;q=0"}})
+		Expect(headers.Get("Content-Language")).To(Equal("en"))
+		Expect(res.Message).To(Equal("item not found"))
+	})
+
+	It("rejects a malformed body", func() {
+		res := server.do(http.MethodPost, "/api/v1/category", "not an object")
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Problem.Code).To(Equal("INVALID_BODY"))
+	})
+
+	It("does not reveal internal errors", func() {
+		db, err := server.connection.DB()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.Close()).To(Succeed())
+
+		res := server.do(http.MethodGet, "/api/v1/items", nil)
+		Expect(res.Code).To(Equal(http.StatusInternalServerError))
+		Expect(res.Problem.Code).To(Equal("INTERNAL_ERROR"))
+		Expect(res.Message).To(Equal("internal server error"))
+
+		res = server.do(http.MethodGet, "/readyz", nil)
+		Expect(res.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(res.Message).To(Equal("database is unreachable"))
+	})
+})
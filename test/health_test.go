@@ -0,0 +1,37 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"net/http"
+)
+
+var _ = Describe("Health", func() {
+	var server *testServer
+
+	BeforeEach(func() {
+		server = newTestServer()
+	})
+
+	It("reports liveness without a session", func() {
+		res := server.do(http.MethodGet, "/healthz", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+	})
+
+	It("reports readiness while the database is reachable", func() {
+		res := server.do(http.MethodGet, "/readyz", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+	})
+
+	It("reports not ready once the database is gone", func() {
+		db, err := server.connection.DB()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.Close()).To(Succeed())
+
+		res := server.do(http.MethodGet, "/readyz", nil)
+		Expect(res.Code).To(Equal(http.StatusServiceUnavailable))
+
+		res = server.do(http.MethodGet, "/healthz", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+	})
+})
@@ -0,0 +1,307 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/gorm"
+	"inventory-management-system/app"
+	"inventory-management-system/config"
+	"inventory-management-system/controller"
+	"inventory-management-system/helper"
+	"inventory-management-system/i18n"
+	"inventory-management-system/logging"
+	"inventory-management-system/metrics"
+	"inventory-management-system/middleware"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"inventory-management-system/oidc"
+	"inventory-management-system/password"
+	"inventory-management-system/ratelimit"
+	"inventory-management-system/repository"
+	"inventory-management-system/service"
+	"inventory-management-system/signing"
+	"inventory-management-system/storage"
+	"inventory-management-system/tracing"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	maxUploadSize    = 1 << 20
+	reorderPoint     = 5
+	loginIPLimit     = 20
+	loginUserLimit   = 10
+	loginMaxFailures = 3
+	lockoutDuration  = time.Minute
+	accessTokenTTL   = 15 * time.Minute
+	refreshTokenTTL  = time.Hour
+	adminPassword    = "Admin-pass-1"
+)
+
+func TestIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Integration Suite")
+}
+
+var spanRecorder = tracetest.NewSpanRecorder()
+
+var idp *mockIdP
+
+var _ = BeforeSuite(func() {
+	_, err := tracing.Setup(context.Background(), config.Tracing{Exporter: "none"})
+	Expect(err).NotTo(HaveOccurred())
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+
+	idp = newMockIdP()
+	DeferCleanup(idp.server.Close)
+})
+
+type response struct {
+	Code    int             `json:"code"`
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Problem web.Problem     `json:"-"`
+}
+
+type testServer struct {
+	engine     *gin.Engine
+	connection *gorm.DB
+	cookie     *http.Cookie
+	refresh    *http.Cookie
+	csrf       *http.Cookie
+	keys       signing.KeySet
+	mailer     *mockMailer
+}
+
+var testCookies = config.Cookie{Enabled: true, Secure: true, SameSite: "strict"}
+
+func newTestServer() *testServer {
+	return newTestServerWithCookies(testCookies)
+}
+
+func newTestServerWithCookies(cookies config.Cookie) *testServer {
+	keys, err := signing.Generate()
+	Expect(err).NotTo(HaveOccurred())
+
+	return buildTestServer(keys, config.MFA{}, cookies)
+}
+
+func newCustomTestServer(keys signing.KeySet, mfa config.MFA) *testServer {
+	return buildTestServer(keys, mfa, testCookies)
+}
+
+func buildTestServer(keys signing.KeySet, mfa config.MFA, cookies config.Cookie) *testServer {
+	mfa.Issuer = "Inventory"
+	mfa.ChallengeTTL = 5 * time.Minute
+
+	database := app.NewSQLite(":memory:")
+	connection, err := database.Connect()
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(func() {
+		Expect(app.Close(connection)).To(Succeed())
+	})
+
+	Expect(connection.Use(tracing.NewGormPlugin())).To(Succeed())
+
+	err = connection.AutoMigrate(
+		domain.Users{}, domain.Sessions{}, domain.Items{}, domain.Categories{}, domain.Activities{},
+		domain.Assignments{}, domain.ItemUnits{}, domain.Attachments{},
+		domain.CategoryAttributes{}, domain.ItemAttributeValues{}, domain.LoginAttempts{}, domain.APIKeys{}, domain.PasswordResets{},
+	)
+	Expect(err).NotTo(HaveOccurred())
+
+	fileStorage, err := storage.NewLocalStorage(GinkgoT().TempDir())
+	Expect(err).NotTo(HaveOccurred())
+
+	timeouts := config.Timeouts{Default: 10 * time.Second, Report: 10 * time.Second, Upload: 10 * time.Second}
+	validate := helper.NewValidator()
+	translator, err := i18n.New(validate)
+	Expect(err).NotTo(HaveOccurred())
+	transactor := repository.NewTransactor(connection)
+	userRepository := repository.NewUserRepository(connection)
+	sessionRepository := repository.NewSessionRepository(connection)
+	itemRepository := repository.NewItemRepository(connection)
+	categoryRepository := repository.NewCategoryRepository(connection)
+	activityRepository := repository.NewActivityRepository(connection)
+	assignmentRepository := repository.NewAssignmentRepository(connection)
+	itemUnitRepository := repository.NewItemUnitRepository(connection)
+	attachmentRepository := repository.NewAttachmentRepository(connection)
+	healthRepository := repository.NewHealthRepository(connection)
+	loginAttemptRepository := repository.NewLoginAttemptRepository(connection)
+	apiKeyRepository := repository.NewAPIKeyRepository(connection)
+	passwordResetRepository := repository.NewPasswordResetRepository(connection)
+	passwords := config.Password{MinLength: 8, MinClasses: 2, ResetTokenTTL: time.Hour, ResetURL: "http://localhost/reset"}
+	passwordPolicy := password.New(passwords)
+	mailer := &mockMailer{}
+	userService := service.NewUserService(transactor, userRepository, sessionRepository, loginAttemptRepository, activityRepository,
+		ratelimit.NewFixedWindow(loginUserLimit, time.Minute), loginMaxFailures, lockoutDuration,
+		accessTokenTTL, refreshTokenTTL, keys, mfa, passwordPolicy)
+	reportService := service.NewReportService(activityRepository, itemRepository)
+	categoryService := service.NewCategoryService(categoryRepository)
+	itemService := service.NewItemService(transactor, itemRepository, categoryRepository, activityRepository)
+	assignmentService := service.NewAssignmentService(transactor, assignmentRepository, itemRepository, itemUnitRepository, userRepository, activityRepository)
+	itemUnitService := service.NewItemUnitService(transactor, itemUnitRepository, itemRepository, activityRepository)
+	labelService := service.NewLabelService(itemRepository, itemUnitRepository)
+	attachmentService := service.NewAttachmentService(transactor, attachmentRepository, itemRepository, activityRepository, fileStorage, maxUploadSize)
+	healthService := service.NewHealthService(healthRepository)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepository)
+	twoFactorService := service.NewTwoFactorService(userRepository, mfa)
+	passwordResetService := service.NewPasswordResetService(transactor, userRepository, sessionRepository, passwordResetRepository, mailer, passwordPolicy, passwords, config.Mail{Timeout: time.Minute, MaxPending: 10})
+	recorder := metrics.New()
+	Expect(recorder.Register(metrics.NewInventoryCollector(itemRepository, reorderPoint))).To(Succeed())
+
+	Expect(helper.RegisterAdmin(context.Background(), userRepository, passwordPolicy, adminPassword)).To(Succeed())
+
+	engine := gin.New()
+	logger := logging.New(GinkgoWriter, slog.LevelDebug)
+	engine.Use(middleware.Tracing(), middleware.RequestID(), middleware.Locale(translator), middleware.Logger(logger), middleware.Metrics(recorder), middleware.Recovery(logger))
+	app.MetricsRouter(engine, recorder)
+	app.HealthRouter(engine, controller.NewHealthController(healthService), timeouts)
+	app.JWKSRouter(engine, keys)
+	authenticate := middleware.Auth(keys, apiKeyService, userService)
+	loginLimiter := ratelimit.NewFixedWindow(loginIPLimit, time.Minute)
+	app.UserRouter(engine, controller.NewUserController(userService, validate, recorder, cookies), authenticate, loginLimiter, timeouts)
+	provider, err := oidc.New(config.OIDC{Issuer: idp.server.URL, ClientID: oidcClientID, ClientSecret: oidcClientSecret, RedirectURL: oidcRedirectURL}, idp.server.Client())
+	Expect(err).NotTo(HaveOccurred())
+	oidcService := service.NewOIDCService(provider, userService, "groups", map[string]string{"inventory-admins": "admin"}, "user")
+	app.OIDCRouter(engine, controller.NewOIDCController(oidcService, recorder, cookies, ""), loginLimiter, timeouts)
+	app.PasswordResetRouter(engine, controller.NewPasswordResetController(passwordResetService, validate), loginLimiter, timeouts)
+	app.ProfileRouter(engine, controller.NewProfileController(userService, validate, cookies), authenticate, timeouts)
+	app.TwoFactorRouter(engine, controller.NewTwoFactorController(twoFactorService, validate), authenticate, timeouts)
+	app.CategoryRouter(engine, controller.NewCategoryController(categoryService, validate), authenticate, timeouts)
+	app.ItemRouter(engine, controller.NewItemController(itemService, validate), authenticate, timeouts)
+	app.ReportRouter(engine, controller.NewReportController(reportService), authenticate, timeouts)
+	app.AssignmentRouter(engine, controller.NewAssignmentController(assignmentService, validate), authenticate, timeouts)
+	app.ItemUnitRouter(engine, controller.NewItemUnitController(itemUnitService, validate), authenticate, timeouts)
+	app.LabelRouter(engine, controller.NewLabelController(labelService, validate), authenticate, timeouts)
+	app.AttachmentRouter(engine, controller.NewAttachmentController(attachmentService, validate), authenticate, maxUploadSize, timeouts)
+	app.APIKeyRouter(engine, controller.NewAPIKeyController(apiKeyService, validate), authenticate, timeouts)
+
+	return &testServer{engine: engine, connection: connection, keys: keys, mailer: mailer}
+}
+
+func (s *testServer) do(method string, path string, body any) response {
+	res, _ := s.doWithHeaders(method, path, body, nil)
+	return res
+}
+
+func (s *testServer) doWithHeaders(method string, path string, body any, headers http.Header) (response, http.Header) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		Expect(err).NotTo(HaveOccurred())
+		reader = bytes.NewReader(payload)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	for name, values := range headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if s.cookie != nil {
+		req.AddCookie(s.cookie)
+	}
+	if s.refresh != nil && strings.HasPrefix(path, s.refresh.Path) {
+		req.AddCookie(s.refresh)
+	}
+	if s.csrf != nil {
+		req.AddCookie(s.csrf)
+		if req.Header.Get(middleware.CSRFHeader) == "" {
+			req.Header.Set(middleware.CSRFHeader, s.csrf.Value)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	s.engine.ServeHTTP(recorder, req)
+
+	for _, cookie := range recorder.Result().Cookies() {
+		name := cookie.Name
+		if !cookie.Expires.IsZero() && cookie.Expires.Before(time.Now()) {
+			cookie = nil
+		}
+
+		switch name {
+		case "session_token":
+			s.cookie = cookie
+		case "refresh_token":
+			s.refresh = cookie
+		case middleware.CSRFCookie:
+			s.csrf = cookie
+		}
+	}
+
+	res := response{}
+	if recorder.Header().Get("Content-Type") == helper.ProblemContentType {
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &res.Problem)).To(Succeed(), recorder.Body.String())
+		res.Code = res.Problem.Status
+		res.Message = res.Problem.Detail
+	} else {
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &res)).To(Succeed(), recorder.Body.String())
+	}
+	Expect(res.Code).To(Equal(recorder.Code))
+	return res, recorder.Header()
+}
+
+func (s *testServer) raw(method string, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	recorder := httptest.NewRecorder()
+	s.engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (s *testServer) fetch(method string, path string, body io.Reader, contentType string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, body)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if s.cookie != nil {
+		req.AddCookie(s.cookie)
+	}
+	if s.csrf != nil {
+		req.AddCookie(s.csrf)
+		req.Header.Set(middleware.CSRFHeader, s.csrf.Value)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (s *testServer) tokens() domain.TokenPair {
+	Expect(s.cookie).NotTo(BeNil())
+	Expect(s.refresh).NotTo(BeNil())
+	return domain.TokenPair{AccessToken: s.cookie.Value, RefreshToken: s.refresh.Value}
+}
+
+func (s *testServer) login(username string, password string) response {
+	s.cookie = nil
+	s.refresh = nil
+	s.csrf = nil
+	return s.do(http.MethodPost, "/api/v1/login", map[string]any{"username": username, "password": password})
+}
+
+func (s *testServer) loginAdmin() {
+	Expect(s.login("administrator", adminPassword).Code).To(Equal(http.StatusOK))
+}
+
+func decode[T any](res response) T {
+	var v T
+	Expect(json.Unmarshal(res.Data, &v)).To(Succeed())
+	return v
+}
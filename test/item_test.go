@@ -0,0 +1,110 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/model/domain"
+	"net/http"
+)
+
+var _ = Describe("Items", func() {
+	var server *testServer
+
+	newItem := func(name string, quantity int, price float64) map[string]any {
+		return map[string]any{
+			"name":          name,
+			"category_id":   1,
+			"quantity":      quantity,
+			"price":         price,
+			"specification": name + " specification",
+		}
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "ram"}).Code).To(Equal(http.StatusCreated))
+	})
+
+	It("creates an item and reads it back", func() {
+		res := server.do(http.MethodPost, "/api/v1/items", newItem("DDR4 8GB", 10, 25))
+		Expect(res.Code).To(Equal(http.StatusCreated))
+
+		res = server.do(http.MethodGet, "/api/v1/items/1", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		item := decode[domain.Items](res)
+		Expect(item.Name).To(Equal("DDR4 8GB"))
+		Expect(item.CategoryID).To(Equal(1))
+		Expect(item.Quantity).To(Equal(10))
+		Expect(item.Price).To(Equal(25.0))
+
+		res = server.do(http.MethodGet, "/api/v1/items", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(decode[[]domain.Items](res)).To(HaveLen(1))
+	})
+
+	It("rejects a duplicate item name", func() {
+		Expect(server.do(http.MethodPost, "/api/v1/items", newItem("DDR4 8GB", 10, 25)).Code).To(Equal(http.StatusCreated))
+
+		res := server.do(http.MethodPost, "/api/v1/items", newItem("DDR4 8GB", 3, 30))
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Problem.Code).To(Equal("ITEM_NAME_TAKEN"))
+		Expect(res.Message).To(Equal("item name is already in use"))
+	})
+
+	It("rejects an item in a missing category", func() {
+		item := newItem("DDR4 8GB", 10, 25)
+		item["category_id"] = 42
+
+		res := server.do(http.MethodPost, "/api/v1/items", item)
+		Expect(res.Code).To(Equal(http.StatusNotFound))
+		Expect(res.Message).To(Equal("category id not found"))
+	})
+
+	It("rejects an invalid item", func() {
+		item := newItem("DDR4 8GB", 10, 25)
+		delete(item, "specification")
+
+		res := server.do(http.MethodPost, "/api/v1/items", item)
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(HavePrefix("validation error"))
+	})
+
+	It("updates an item", func() {
+		Expect(server.do(http.MethodPost, "/api/v1/items", newItem("DDR4 8GB", 10, 25)).Code).To(Equal(http.StatusCreated))
+
+		res := server.do(http.MethodPut, "/api/v1/items/1", newItem("DDR4 16GB", 4, 40))
+		Expect(res.Code).To(Equal(http.StatusOK))
+
+		item := decode[domain.Items](server.do(http.MethodGet, "/api/v1/items/1", nil))
+		Expect(item.Name).To(Equal("DDR4 16GB"))
+		Expect(item.Quantity).To(Equal(4))
+		Expect(item.Price).To(Equal(40.0))
+	})
+
+	It("reports a missing item on update", func() {
+		res := server.do(http.MethodPut, "/api/v1/items/7", newItem("DDR4 16GB", 4, 40))
+		Expect(res.Code).To(Equal(http.StatusNotFound))
+		Expect(res.Message).To(Equal("item id not found"))
+	})
+
+	It("deletes an item", func() {
+		Expect(server.do(http.MethodPost, "/api/v1/items", newItem("DDR4 8GB", 10, 25)).Code).To(Equal(http.StatusCreated))
+
+		Expect(server.do(http.MethodDelete, "/api/v1/items/1", nil).Code).To(Equal(http.StatusOK))
+
+		res := server.do(http.MethodGet, "/api/v1/items/1", nil)
+		Expect(res.Code).To(Equal(http.StatusNotFound))
+
+		res = server.do(http.MethodDelete, "/api/v1/items/1", nil)
+		Expect(res.Code).To(Equal(http.StatusNotFound))
+		Expect(res.Message).To(Equal("item id not found"))
+	})
+
+	It("rejects a duplicate category name", func() {
+		res := server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "ram"})
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Problem.Code).To(Equal("CATEGORY_NAME_TAKEN"))
+		Expect(res.Message).To(Equal("category already exists"))
+	})
+})
@@ -0,0 +1,120 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/model/domain"
+	"net/http"
+	"time"
+)
+
+var _ = Describe("Item units", func() {
+	var server *testServer
+
+	quantity := func() int {
+		res := server.do(http.MethodGet, "/api/v1/items/1", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		return decode[domain.Items](res).Quantity
+	}
+
+	addUnit := func(serialNumber string, assetTag string) response {
+		return server.do(http.MethodPost, "/api/v1/items/1/units", map[string]any{"serial_number": serialNumber, "asset_tag": assetTag})
+	}
+
+	setStatus := func(unitID string, status string) response {
+		return server.do(http.MethodPut, "/api/v1/units/"+unitID, map[string]any{"status": status, "note": "inspection"})
+	}
+
+	checkOut := func(unitID int) response {
+		return server.do(http.MethodPost, "/api/v1/assignments/checkout", map[string]any{
+			"item_id": 1, "assignee": "administrator", "unit_id": unitID, "due_date": time.Now().Add(time.Hour),
+		})
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "laptop"}).Code).To(Equal(http.StatusCreated))
+		Expect(server.do(http.MethodPost, "/api/v1/items", map[string]any{
+			"name": "ThinkPad T14", "category_id": 1, "price": 1200, "specification": "16GB", "serialized": true,
+		}).Code).To(Equal(http.StatusCreated))
+	})
+
+	It("counts units in stock as the item quantity", func() {
+		res := addUnit("SN-001", "TAG-001")
+		Expect(res.Code).To(Equal(http.StatusCreated))
+		unit := decode[domain.ItemUnits](res)
+		Expect(unit.Status).To(Equal(domain.UnitStatusInStock))
+		Expect(addUnit("SN-002", "TAG-002").Code).To(Equal(http.StatusCreated))
+		Expect(quantity()).To(Equal(2))
+
+		Expect(decode[domain.ItemUnits](server.do(http.MethodGet, "/api/v1/units/serial/SN-002", nil)).AssetTag).To(Equal("TAG-002"))
+		Expect(decode[domain.ItemUnits](server.do(http.MethodGet, "/api/v1/units/tag/TAG-001", nil)).SerialNumber).To(Equal("SN-001"))
+		Expect(decode[[]domain.ItemUnits](server.do(http.MethodGet, "/api/v1/items/1/units", nil))).To(HaveLen(2))
+	})
+
+	It("rejects duplicate identifiers and units of plain items", func() {
+		Expect(addUnit("SN-001", "TAG-001").Code).To(Equal(http.StatusCreated))
+
+		res := addUnit("SN-001", "TAG-009")
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Problem.Code).To(Equal("SERIAL_NUMBER_TAKEN"))
+		Expect(addUnit("SN-009", "TAG-001").Problem.Code).To(Equal("ASSET_TAG_TAKEN"))
+
+		Expect(server.do(http.MethodPost, "/api/v1/items", map[string]any{
+			"name": "Mouse", "category_id": 1, "quantity": 5, "price": 10, "specification": "usb",
+		}).Code).To(Equal(http.StatusCreated))
+		res = server.do(http.MethodPost, "/api/v1/items/2/units", map[string]any{"serial_number": "SN-003", "asset_tag": "TAG-003"})
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("item is not serialized"))
+	})
+
+	It("moves a unit through repair and retirement", func() {
+		Expect(addUnit("SN-001", "TAG-001").Code).To(Equal(http.StatusCreated))
+
+		Expect(setStatus("1", domain.UnitStatusInRepair).Code).To(Equal(http.StatusOK))
+		Expect(quantity()).To(Equal(0))
+
+		res := setStatus("1", domain.UnitStatusInRepair)
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Message).To(Equal("unit already has status in_repair"))
+
+		Expect(setStatus("1", domain.UnitStatusInStock).Code).To(Equal(http.StatusOK))
+		Expect(quantity()).To(Equal(1))
+
+		Expect(setStatus("1", domain.UnitStatusRetired).Code).To(Equal(http.StatusOK))
+		unit := decode[domain.ItemUnits](server.do(http.MethodGet, "/api/v1/units/1", nil))
+		Expect(unit.Status).To(Equal(domain.UnitStatusRetired))
+		Expect(unit.Note).To(Equal("inspection"))
+		Expect(quantity()).To(Equal(0))
+
+		Expect(setStatus("1", domain.UnitStatusAssigned).Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("assigns a unit once and frees it on check-in", func() {
+		Expect(addUnit("SN-001", "TAG-001").Code).To(Equal(http.StatusCreated))
+
+		res := checkOut(1)
+		Expect(res.Code).To(Equal(http.StatusCreated))
+		Expect(decode[domain.Assignments](res).Quantity).To(Equal(1))
+		Expect(quantity()).To(Equal(0))
+
+		res = checkOut(1)
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Message).To(Equal("unit is not in stock"))
+
+		res = setStatus("1", domain.UnitStatusRetired)
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Message).To(Equal("unit is assigned, check it in first"))
+
+		res = server.do(http.MethodPost, "/api/v1/assignments/checkout", map[string]any{
+			"item_id": 1, "assignee": "administrator", "quantity": 1, "due_date": time.Now().Add(time.Hour),
+		})
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("unit id is required for serialized item"))
+
+		Expect(server.do(http.MethodPost, "/api/v1/assignments/1/checkin", nil).Code).To(Equal(http.StatusOK))
+		Expect(decode[domain.ItemUnits](server.do(http.MethodGet, "/api/v1/units/1", nil)).Status).To(Equal(domain.UnitStatusInStock))
+		Expect(quantity()).To(Equal(1))
+	})
+})
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"image/png"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"net/http"
+	"strings"
+)
+
+var _ = Describe("Labels", func() {
+	var server *testServer
+
+	addUnit := func(serialNumber string, assetTag string) response {
+		return server.do(http.MethodPost, "/api/v1/items/1/units", map[string]any{"serial_number": serialNumber, "asset_tag": assetTag})
+	}
+
+	problem := func(body []byte) web.Problem {
+		var problem web.Problem
+		Expect(json.Unmarshal(body, &problem)).To(Succeed())
+		return problem
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "laptop"}).Code).To(Equal(http.StatusCreated))
+		Expect(server.do(http.MethodPost, "/api/v1/items", map[string]any{
+			"name": "Café laptop — ThinkPad T14 gen 4 with extended battery", "category_id": 1, "price": 1200, "specification": "16GB", "serialized": true,
+		}).Code).To(Equal(http.StatusCreated))
+	})
+
+	It("renders an item label as png", func() {
+		recorder := server.fetch(http.MethodGet, "/api/v1/items/1/label", nil, "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("image/png"))
+		Expect(recorder.Header().Get("Content-Disposition")).To(Equal(`inline; filename="item-1.png"`))
+
+		img, err := png.Decode(recorder.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(img.Bounds().Dx()).To(Equal(600))
+		Expect(img.Bounds().Dy()).To(Equal(260))
+	})
+
+	It("widens the png label for long asset tags", func() {
+		Expect(addUnit("SN-001", strings.Repeat("TAG-", 20)).Code).To(Equal(http.StatusCreated))
+
+		recorder := server.fetch(http.MethodGet, "/api/v1/units/1/label", nil, "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		img, err := png.Decode(recorder.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(img.Bounds().Dx()).To(BeNumerically(">", 600))
+
+		Expect(server.fetch(http.MethodGet, "/api/v1/units/1/label?format=pdf", nil, "").Code).To(Equal(http.StatusOK))
+	})
+
+	It("renders a batch of labels as pdf", func() {
+		Expect(addUnit("SN-001", "TAG-001").Code).To(Equal(http.StatusCreated))
+		Expect(addUnit("SN-002", "TAG-002").Code).To(Equal(http.StatusCreated))
+
+		recorder := server.fetch(http.MethodPost, "/api/v1/labels", strings.NewReader(`{"item_ids":[1],"unit_ids":[1,2]}`), "application/json")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/pdf"))
+		Expect(bytes.HasPrefix(recorder.Body.Bytes(), []byte("%PDF-"))).To(BeTrue())
+
+		recorder = server.fetch(http.MethodPost, "/api/v1/labels", strings.NewReader(`{"unit_ids":[7]}`), "application/json")
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		Expect(problem(recorder.Body.Bytes()).Detail).To(Equal("unit id 7 not found"))
+	})
+
+	It("rejects unknown label formats", func() {
+		recorder := server.fetch(http.MethodGet, "/api/v1/items/1/label?format=svg", nil, "")
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(problem(recorder.Body.Bytes()).Code).To(Equal("LABEL_FORMAT_INVALID"))
+	})
+
+	It("refuses asset tags that cannot be printed as a barcode", func() {
+		res := addUnit("SN-001", "TAG-ÄÖÜ")
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Problem.Errors).To(ConsistOf(HaveField("Field", "asset_tag")))
+		Expect(addUnit("SN-001", strings.Repeat("T", 81)).Code).To(Equal(http.StatusBadRequest))
+
+		Expect(server.connection.Create(&domain.ItemUnits{ItemID: 1, SerialNumber: "SN-LEGACY", AssetTag: "ÉTIQUETTE", Status: domain.UnitStatusInStock}).Error).To(Succeed())
+		recorder := server.fetch(http.MethodGet, "/api/v1/units/1/label", nil, "")
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(problem(recorder.Body.Bytes()).Code).To(Equal("LABEL_CODE_INVALID"))
+	})
+
+	It("resolves scanned codes", func() {
+		Expect(addUnit("SN-001", "TAG-001").Code).To(Equal(http.StatusCreated))
+
+		result := decode[domain.ScanResult](server.do(http.MethodGet, "/api/v1/scan/ITEM-000001", nil))
+		Expect(result.Type).To(Equal("item"))
+		Expect(result.Item.ID).To(Equal(1))
+
+		result = decode[domain.ScanResult](server.do(http.MethodGet, "/api/v1/scan/SN-001", nil))
+		Expect(result.Type).To(Equal("unit"))
+		Expect(result.Unit.AssetTag).To(Equal("TAG-001"))
+
+		Expect(server.do(http.MethodGet, "/api/v1/scan/unknown", nil).Code).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,58 @@
+package test
+
+import (
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/middleware"
+	"inventory-management-system/model/domain"
+	"net/http"
+)
+
+var _ = Describe("Request logging", func() {
+	var server *testServer
+
+	BeforeEach(func() {
+		server = newTestServer()
+	})
+
+	It("generates a request id when none is sent", func() {
+		_, headers := server.doWithHeaders(http.MethodGet, "/healthz", nil, nil)
+		Expect(headers.Get(middleware.RequestIDHeader)).To(MatchRegexp("^[0-9a-f]{32}$"))
+	})
+
+	It("echoes the caller's request id", func() {
+		_, headers := server.doWithHeaders(http.MethodGet, "/healthz", nil, http.Header{
+			middleware.RequestIDHeader: {"trace-123"},
+		})
+		Expect(headers.Get(middleware.RequestIDHeader)).To(Equal("trace-123"))
+	})
+
+	It("records the request id on activities", func() {
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "cpu"}).Code).To(Equal(http.StatusCreated))
+
+		res, _ := server.doWithHeaders(http.MethodPost, "/api/v1/items", map[string]any{
+			"name":          "Ryzen 5",
+			"category_id":   1,
+			"quantity":      4,
+			"price":         120,
+			"specification": "6 cores",
+		}, http.Header{middleware.RequestIDHeader: {"create-ryzen"}})
+		Expect(res.Code).To(Equal(http.StatusCreated))
+
+		activities := decode[[]domain.Activities](server.do(http.MethodGet, "/api/v1/reports/activity", nil))
+		Expect(activities).To(HaveLen(1))
+		Expect(activities[0].RequestID).To(Equal("create-ryzen"))
+	})
+
+	It("turns a panic into an internal server error", func() {
+		server.engine.GET("/panic", func(c *gin.Context) {
+			panic("boom")
+		})
+
+		res := server.do(http.MethodGet, "/panic", nil)
+		Expect(res.Code).To(Equal(http.StatusInternalServerError))
+		Expect(res.Message).To(Equal("internal server error"))
+	})
+})
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/model/domain"
+	"net/http"
+	"time"
+)
+
+var _ = Describe("Login protection", func() {
+	var server *testServer
+
+	BeforeEach(func() {
+		server = newTestServer()
+	})
+
+	It("locks an account after repeated failures", func() {
+		for i := 0; i < loginMaxFailures; i++ {
+			res := server.login("administrator", "wrong-password")
+			Expect(res.Code).To(Equal(http.StatusBadRequest))
+		}
+
+		res := server.login("administrator", adminPassword)
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(server.cookie).To(BeNil())
+
+		unknown := server.login("nobody", adminPassword)
+		Expect(res.Problem).To(Equal(unknown.Problem))
+	})
+
+	It("unlocks the account once the lockout expires", func() {
+		for i := 0; i < loginMaxFailures; i++ {
+			server.login("administrator", "wrong-password")
+		}
+
+		err := server.connection.Model(&domain.Users{}).Where("username = ?", "administrator").
+			Update("locked_until", time.Now().Add(-time.Second)).Error
+		Expect(err).NotTo(HaveOccurred())
+
+		server.loginAdmin()
+		user := decode[domain.Users](server.do(http.MethodGet, "/api/v1/users/administrator", nil))
+		Expect(user.FailedLogins).To(BeZero())
+		Expect(user.LockedUntil).To(BeNil())
+	})
+
+	It("resets the failure count after a successful login", func() {
+		for i := 0; i < loginMaxFailures-1; i++ {
+			server.login("administrator", "wrong-password")
+		}
+		server.loginAdmin()
+
+		for i := 0; i < loginMaxFailures-1; i++ {
+			server.login("administrator", "wrong-password")
+		}
+		server.loginAdmin()
+	})
+
+	It("limits attempts per username", func() {
+		for i := 0; i < loginUserLimit; i++ {
+			Expect(server.login("nobody", "password").Code).To(Equal(http.StatusBadRequest))
+		}
+
+		Expect(server.login("nobody", "password").Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("limits attempts per client address", func() {
+		for i := 0; i < loginIPLimit; i++ {
+			Expect(server.login(fmt.Sprintf("user%d", i), "password").Code).To(Equal(http.StatusBadRequest))
+		}
+
+		res, headers := server.doWithHeaders(http.MethodPost, "/api/v1/login", map[string]any{"username": "administrator", "password": adminPassword}, nil)
+		Expect(res.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(headers.Get("Retry-After")).NotTo(BeEmpty())
+	})
+
+	It("keeps an audit trail for admins", func() {
+		server.login("nobody", "password")
+		server.login("administrator", "wrong-password")
+		server.loginAdmin()
+
+		res := server.do(http.MethodGet, "/api/v1/login-attempts", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		attempts := decode[[]domain.LoginAttempts](res)
+		Expect(attempts).To(HaveLen(3))
+		Expect(attempts[0].Reason).To(Equal(domain.LoginUnknownUser))
+		Expect(attempts[1].Reason).To(Equal(domain.LoginInvalidPassword))
+		Expect(attempts[2].Reason).To(Equal(domain.LoginSuccess))
+		Expect(attempts[2].Success).To(BeTrue())
+		Expect(attempts[2].IPAddress).NotTo(BeEmpty())
+
+		res = server.do(http.MethodGet, "/api/v1/login-attempts?username=nobody", nil)
+		Expect(decode[[]domain.LoginAttempts](res)).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,475 @@
+package test
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/config"
+	"inventory-management-system/helper"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/model/web"
+	"inventory-management-system/password"
+	"inventory-management-system/ratelimit"
+	"inventory-management-system/repository"
+	"inventory-management-system/repository/memory"
+	"inventory-management-system/service"
+	"inventory-management-system/signing"
+	"inventory-management-system/totp"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+func concurrently(times int, fn func() web.ErrorResponse) []web.ErrorResponse {
+	var wg sync.WaitGroup
+	results := make([]web.ErrorResponse, times)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer GinkgoRecover()
+			defer wg.Done()
+			results[i] = fn()
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func succeeded(results []web.ErrorResponse) int {
+	count := 0
+	for _, result := range results {
+		if result == nil {
+			count++
+		}
+	}
+
+	return count
+}
+
+func addMemoryUser(users repository.UserRepository, username string, role string, email string) {
+	hashedPassword, err := helper.HashPassword(username + "-Pass-7")
+	Expect(err).NotTo(HaveOccurred())
+
+	user := domain.Users{FullName: username, Username: username, Password: hashedPassword, Role: role, Active: true}
+	if email != "" {
+		user.Email = &email
+	}
+	Expect(users.Create(context.Background(), &user)).To(Succeed())
+}
+
+// lockstep holds the first few lookups until all of them have read, so every caller starts from the same snapshot.
+type lockstep struct {
+	mu      sync.Mutex
+	pending int
+	reads   sync.WaitGroup
+}
+
+func (l *lockstep) hold(readers int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.pending = readers
+	l.reads.Add(readers)
+}
+
+func (l *lockstep) wait() {
+	l.mu.Lock()
+	held := l.pending > 0
+	if held {
+		l.pending--
+	}
+	l.mu.Unlock()
+
+	if held {
+		l.reads.Done()
+		l.reads.Wait()
+	}
+}
+
+type lockstepUsers struct {
+	repository.UserRepository
+	lockstep
+}
+
+func (l *lockstepUsers) FindByUsername(ctx context.Context, username string) (domain.Users, error) {
+	user, err := l.UserRepository.FindByUsername(ctx, username)
+	l.wait()
+	return user, err
+}
+
+type lockstepItems struct {
+	repository.ItemRepository
+	lockstep
+}
+
+func (l *lockstepItems) FindByName(ctx context.Context, name string) (domain.Items, error) {
+	item, err := l.ItemRepository.FindByName(ctx, name)
+	l.wait()
+	return item, err
+}
+
+var _ = Describe("Services on the in-memory store", func() {
+	ctx := context.Background()
+	passwords := config.Password{MinLength: 8, MinClasses: 2, ResetTokenTTL: time.Hour, ResetURL: "http://localhost/reset"}
+	mails := config.Mail{Timeout: time.Minute, MaxPending: 10}
+
+	var (
+		store       *memory.Store
+		transactor  repository.Transactor
+		users       repository.UserRepository
+		sessions    repository.SessionRepository
+		items       repository.ItemRepository
+		units       repository.ItemUnitRepository
+		assignments repository.AssignmentRepository
+		activities  repository.ActivityRepository
+	)
+
+	BeforeEach(func() {
+		store = memory.NewStore()
+		transactor = memory.NewTransactor(store)
+		users = memory.NewUserRepository(store)
+		sessions = memory.NewSessionRepository(store)
+		items = memory.NewItemRepository(store)
+		units = memory.NewItemUnitRepository(store)
+		assignments = memory.NewAssignmentRepository(store)
+		activities = memory.NewActivityRepository(store)
+	})
+
+	Describe("AssignmentService", func() {
+		var assignmentService service.AssignmentService
+
+		checkOut := func(itemID int, unitID int, amount int) web.ErrorResponse {
+			_, errResponse := assignmentService.CheckOut(ctx, web.AssignmentCheckOutRequest{
+				ItemID: itemID, Assignee: "janedoe", UnitID: unitID, Quantity: amount, DueDate: time.Now().Add(time.Hour),
+			}, "administrator")
+			return errResponse
+		}
+
+		quantity := func(itemID int) int {
+			item, err := items.FindByID(ctx, itemID)
+			Expect(err).NotTo(HaveOccurred())
+			return item.Quantity
+		}
+
+		BeforeEach(func() {
+			assignmentService = service.NewAssignmentService(transactor, assignments, items, units, users, activities)
+			addMemoryUser(users, "janedoe", "user", "")
+			Expect(items.Create(ctx, &domain.Items{Name: "HDMI cable", Quantity: 10})).To(Succeed())
+			Expect(items.Create(ctx, &domain.Items{Name: "ThinkPad T14", Serialized: true})).To(Succeed())
+			Expect(units.Create(ctx, &domain.ItemUnits{ItemID: 2, SerialNumber: "SN-001", AssetTag: "TAG-001", Status: domain.UnitStatusInStock})).To(Succeed())
+			Expect(items.SyncUnitStock(ctx, 2)).To(Succeed())
+		})
+
+		It("moves stock out and back with an activity for each step", func() {
+			Expect(checkOut(1, 0, 4)).To(BeNil())
+			Expect(quantity(1)).To(Equal(6))
+
+			Expect(assignmentService.CheckIn(ctx, web.AssignmentCheckInRequest{ID: 1, Note: "intact"}, "administrator")).To(BeNil())
+			Expect(quantity(1)).To(Equal(10))
+
+			logged, err := activities.FindAll(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logged).To(ConsistOf(
+				HaveField("Action", domain.ActionCheckOut),
+				HaveField("Action", domain.ActionCheckIn),
+			))
+		})
+
+		It("returns stock once when the same assignment is checked in concurrently", func() {
+			Expect(checkOut(1, 0, 3)).To(BeNil())
+
+			results := concurrently(8, func() web.ErrorResponse {
+				return assignmentService.CheckIn(ctx, web.AssignmentCheckInRequest{ID: 1}, "administrator")
+			})
+			Expect(succeeded(results)).To(Equal(1))
+			for _, result := range results {
+				if result != nil {
+					Expect(result.ErrorCode()).To(Equal(web.CodeAssignmentAlreadyCheckedIn))
+				}
+			}
+			Expect(quantity(1)).To(Equal(10))
+		})
+
+		It("never takes more than is in stock under concurrent check-outs", func() {
+			results := concurrently(8, func() web.ErrorResponse { return checkOut(1, 0, 3) })
+			Expect(succeeded(results)).To(Equal(3))
+			Expect(quantity(1)).To(Equal(1))
+
+			open, err := assignments.FindOpen(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(open).To(HaveLen(3))
+		})
+
+		It("assigns a unit to a single holder under concurrent check-outs", func() {
+			results := concurrently(8, func() web.ErrorResponse { return checkOut(2, 1, 0) })
+			Expect(succeeded(results)).To(Equal(1))
+			for _, result := range results {
+				if result != nil {
+					Expect(result.ErrorCode()).To(Equal(web.CodeUnitNotInStock))
+				}
+			}
+
+			unit, err := units.FindByID(ctx, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unit.Status).To(Equal(domain.UnitStatusAssigned))
+			Expect(quantity(2)).To(Equal(0))
+		})
+	})
+
+	Describe("UserService", func() {
+		var userService service.UserService
+
+		login := func(username string, pass string) (domain.TokenPair, web.ErrorResponse) {
+			tokens, _, errResponse := userService.Login(ctx, &web.UserLoginRequest{Username: username, Password: pass}, "127.0.0.1")
+			return tokens, errResponse
+		}
+
+		BeforeEach(func() {
+			keys, err := signing.Generate()
+			Expect(err).NotTo(HaveOccurred())
+
+			userService = service.NewUserService(transactor, users, sessions, memory.NewLoginAttemptRepository(store), activities,
+				ratelimit.NewFixedWindow(loginUserLimit, time.Minute), loginMaxFailures, lockoutDuration,
+				accessTokenTTL, refreshTokenTTL, keys, config.MFA{Issuer: "Inventory"}, password.New(passwords))
+			addMemoryUser(users, "alice01", "admin", "alice@example.com")
+		})
+
+		It("registers a user once", func() {
+			request := &web.UserRegisterRequest{FullName: "Jane Doe", Username: "janedoe", Password: "Jane-pass-7", Role: "user"}
+			Expect(userService.Register(ctx, request)).To(BeNil())
+
+			errResponse := userService.Register(ctx, request)
+			Expect(errResponse).NotTo(BeNil())
+			Expect(errResponse.ErrorCode()).To(Equal(web.CodeUsernameTaken))
+
+			_, errResponse = login("janedoe", "Jane-pass-7")
+			Expect(errResponse).To(BeNil())
+		})
+
+		It("keeps emails unique", func() {
+			request := &web.UserRegisterRequest{FullName: "Jane Doe", Username: "janedoe", Password: "Jane-pass-7", Role: "user", Email: "Alice@Example.com"}
+			errResponse := userService.Register(ctx, request)
+			Expect(errResponse).NotTo(BeNil())
+			Expect(errResponse.ErrorCode()).To(Equal(web.CodeEmailTaken))
+
+			request.Email = ""
+			Expect(userService.Register(ctx, request)).To(BeNil())
+			errResponse = userService.Update(ctx, web.UserUpdateRequest{FullName: "Jane Doe", Username: "janedoe", Password: "Jane-pass-7", Role: "user", Email: "alice@example.com"})
+			Expect(errResponse).NotTo(BeNil())
+			Expect(errResponse.ErrorCode()).To(Equal(web.CodeEmailTaken))
+		})
+
+		It("seeds the administrator once with a password that passes the policy", func() {
+			Expect(helper.RegisterAdmin(ctx, users, password.New(passwords), "admin123")).To(MatchError(password.ErrBreached))
+			_, err := users.FindByUsername(ctx, "administrator")
+			Expect(err).To(MatchError(repository.ErrNotFound))
+
+			Expect(helper.RegisterAdmin(ctx, users, password.New(passwords), adminPassword)).To(Succeed())
+			Expect(helper.RegisterAdmin(ctx, users, password.New(passwords), "Other-pass-2")).To(Succeed())
+			_, errResponse := login("administrator", adminPassword)
+			Expect(errResponse).To(BeNil())
+		})
+
+		It("spends a recovery code once when it is redeemed concurrently", func() {
+			twoFactorService := service.NewTwoFactorService(users, config.MFA{Issuer: "Inventory"})
+			enrollment, errResponse := twoFactorService.Enroll(ctx, "alice01")
+			Expect(errResponse).To(BeNil())
+			code, err := totp.Code(enrollment.Secret, totp.Step(time.Now()))
+			Expect(err).NotTo(HaveOccurred())
+			recoveryCodes, errResponse := twoFactorService.Confirm(ctx, "alice01", code)
+			Expect(errResponse).To(BeNil())
+
+			keys, err := signing.Generate()
+			Expect(err).NotTo(HaveOccurred())
+			lockstep := &lockstepUsers{UserRepository: users}
+			userService = service.NewUserService(transactor, lockstep, sessions, memory.NewLoginAttemptRepository(store), activities,
+				ratelimit.NewFixedWindow(loginUserLimit, time.Minute), loginMaxFailures, lockoutDuration,
+				accessTokenTTL, refreshTokenTTL, keys, config.MFA{Issuer: "Inventory", ChallengeTTL: time.Minute}, password.New(passwords))
+			_, challenge, errResponse := userService.Login(ctx, &web.UserLoginRequest{Username: "alice01", Password: "alice01-Pass-7"}, "127.0.0.1")
+			Expect(errResponse).To(BeNil())
+			Expect(challenge).NotTo(BeNil())
+			enrolled, err := users.FindByUsername(ctx, "alice01")
+			Expect(err).NotTo(HaveOccurred())
+
+			lockstep.hold(2)
+
+			results := concurrently(2, func() web.ErrorResponse {
+				_, errResponse := userService.VerifyMFA(ctx, web.MFAVerifyRequest{MFAToken: challenge.MFAToken, Code: recoveryCodes[0]}, "127.0.0.1")
+				return errResponse
+			})
+			Expect(succeeded(results)).To(Equal(1))
+
+			redeemed, err := users.FindByUsername(ctx, "alice01")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(redeemed.RecoveryCodes).To(HaveLen(len(recoveryCodes) - 1))
+			Expect(redeemed.TOTPLastStep).To(Equal(enrolled.TOTPLastStep))
+		})
+
+		It("locks an account after repeated failures", func() {
+			for range loginMaxFailures {
+				_, errResponse := login("alice01", "wrong-password-1")
+				Expect(errResponse).NotTo(BeNil())
+			}
+
+			_, errResponse := login("alice01", "alice01-Pass-7")
+			Expect(errResponse).NotTo(BeNil())
+			Expect(errResponse.ErrorCode()).To(Equal(web.CodeInvalidCredentials))
+
+			attempts, errResponse := userService.GetLoginAttempts(ctx, "alice01")
+			Expect(errResponse).To(BeNil())
+			Expect(attempts).To(HaveLen(loginMaxFailures + 1))
+		})
+
+		It("revokes the sessions of a deactivated user", func() {
+			addMemoryUser(users, "janedoe", "user", "")
+			tokens, errResponse := login("janedoe", "janedoe-Pass-7")
+			Expect(errResponse).To(BeNil())
+
+			_, errResponse = userService.VerifySession(ctx, tokens.AccessToken)
+			Expect(errResponse).To(BeNil())
+
+			Expect(userService.Deactivate(ctx, "janedoe", "alice01")).To(BeNil())
+			_, errResponse = userService.VerifySession(ctx, tokens.AccessToken)
+			Expect(errResponse).NotTo(BeNil())
+			Expect(errResponse.ErrorCode()).To(Equal(web.CodeTokenRevoked))
+		})
+
+		It("keeps one admin active when two are deactivated at once", func() {
+			addMemoryUser(users, "bobby01", "admin", "")
+			addMemoryUser(users, "carol01", "user", "")
+
+			results := make([]web.ErrorResponse, 2)
+			var wg sync.WaitGroup
+			for i, username := range []string{"alice01", "bobby01"} {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					results[i] = userService.Deactivate(ctx, username, "carol01")
+				}()
+			}
+			wg.Wait()
+
+			Expect(succeeded(results)).To(Equal(1))
+			count, err := users.LockActiveByRole(ctx, "admin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+		})
+	})
+
+	Describe("ItemService", func() {
+		var (
+			lockstep    *lockstepItems
+			itemService service.ItemService
+		)
+
+		BeforeEach(func() {
+			categories := memory.NewCategoryRepository(store)
+			Expect(categories.Create(ctx, &domain.Categories{Name: "cable"})).To(Succeed())
+			lockstep = &lockstepItems{ItemRepository: items}
+			itemService = service.NewItemService(transactor, lockstep, categories, activities)
+		})
+
+		It("lets only one of two concurrent requests take a name", func() {
+			add := func(name string) web.ErrorResponse {
+				return itemService.Add(ctx, web.ItemAddRequest{Name: name, CategoryID: 1, Quantity: 1, Price: 5, Specification: "2m"}, "admin")
+			}
+			lockstep.hold(2)
+			results := concurrently(2, func() web.ErrorResponse { return add("HDMI cable") })
+
+			Expect(succeeded(results)).To(Equal(1))
+			for _, result := range results {
+				if result != nil {
+					Expect(result.ErrorCode()).To(Equal(web.CodeItemNameTaken))
+				}
+			}
+
+			Expect(add("USB cable")).To(BeNil())
+			errResponse := itemService.Update(ctx, web.ItemUpdateRequest{ID: 2, Name: "HDMI cable", CategoryID: 1, Quantity: 1, Price: 5, Specification: "2m"}, "admin")
+			Expect(errResponse).NotTo(BeNil())
+			Expect(errResponse.ErrorCode()).To(Equal(web.CodeItemNameTaken))
+		})
+
+		It("answers not found for a missing item", func() {
+			errResponse := itemService.Delete(ctx, 1, "admin")
+			Expect(errResponse).NotTo(BeNil())
+			Expect(errResponse.Code()).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("PasswordResetService", func() {
+		var (
+			mailer               *mockMailer
+			passwordResetService service.PasswordResetService
+		)
+
+		lastToken := func(count int) string {
+			messages := mailer.delivered(count)
+			match := resetLinkPattern.FindStringSubmatch(messages[len(messages)-1].Body)
+			Expect(match).To(HaveLen(2))
+			token, err := url.QueryUnescape(match[1])
+			Expect(err).NotTo(HaveOccurred())
+			return token
+		}
+
+		BeforeEach(func() {
+			mailer = &mockMailer{}
+			passwordResetService = service.NewPasswordResetService(transactor, users, sessions, memory.NewPasswordResetRepository(store), mailer, password.New(passwords), passwords, mails)
+			addMemoryUser(users, "janedoe", "user", "jane@example.com")
+		})
+
+		It("mails nothing for unknown addresses", func() {
+			Expect(passwordResetService.Request(ctx, "nobody@example.com")).To(BeNil())
+			Consistently(mailer.sent, 50*time.Millisecond).Should(BeEmpty())
+		})
+
+		It("sets a new password with a single-use token", func() {
+			Expect(sessions.Create(ctx, &domain.Sessions{Username: "janedoe", Token: "access", FamilyID: "family"})).To(Succeed())
+			Expect(passwordResetService.Request(ctx, "Jane@Example.com")).To(BeNil())
+			token := lastToken(1)
+
+			Expect(passwordResetService.Confirm(ctx, web.PasswordResetConfirmRequest{Token: token, NewPassword: "New-pass-42"})).To(BeNil())
+			user, err := users.FindByUsername(ctx, "janedoe")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(helper.CheckPasswordHash("New-pass-42", user.Password)).To(BeTrue())
+
+			session, err := sessions.FindByToken(ctx, "access")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.RevokedAt).NotTo(BeNil())
+
+			errResponse := passwordResetService.Confirm(ctx, web.PasswordResetConfirmRequest{Token: token, NewPassword: "Other-pass-42"})
+			Expect(errResponse).NotTo(BeNil())
+			Expect(errResponse.ErrorCode()).To(Equal(web.CodeResetTokenInvalid))
+		})
+
+		It("invalidates an older token when a new one is requested", func() {
+			Expect(passwordResetService.Request(ctx, "jane@example.com")).To(BeNil())
+			first := lastToken(1)
+			Expect(passwordResetService.Request(ctx, "jane@example.com")).To(BeNil())
+			second := lastToken(2)
+
+			errResponse := passwordResetService.Confirm(ctx, web.PasswordResetConfirmRequest{Token: first, NewPassword: "New-pass-42"})
+			Expect(errResponse).NotTo(BeNil())
+			Expect(errResponse.ErrorCode()).To(Equal(web.CodeResetTokenInvalid))
+			Expect(passwordResetService.Confirm(ctx, web.PasswordResetConfirmRequest{Token: second, NewPassword: "New-pass-42"})).To(BeNil())
+		})
+
+		It("mails under a deadline and drops requests beyond the pending limit", func() {
+			passwordResetService = service.NewPasswordResetService(transactor, users, sessions, memory.NewPasswordResetRepository(store), mailer, password.New(passwords), passwords, config.Mail{Timeout: time.Minute, MaxPending: 1})
+			gate := mailer.hold()
+			Expect(passwordResetService.Request(ctx, "jane@example.com")).To(BeNil())
+			Expect(passwordResetService.Request(ctx, "jane@example.com")).To(BeNil())
+
+			drainCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+			Expect(passwordResetService.Drain(drainCtx)).To(MatchError(context.DeadlineExceeded))
+
+			close(gate)
+			Expect(passwordResetService.Drain(ctx)).To(Succeed())
+			Expect(mailer.sent()).To(HaveLen(1))
+			Expect(mailer.deadlines()).To(ConsistOf(BeTemporally("~", time.Now().Add(time.Minute), 5*time.Second)))
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"net/http"
+)
+
+var _ = Describe("Metrics", func() {
+	var server *testServer
+
+	BeforeEach(func() {
+		server = newTestServer()
+	})
+
+	scrape := func() string {
+		res := server.raw(http.MethodGet, "/metrics")
+		Expect(res.Code).To(Equal(http.StatusOK))
+		return res.Body.String()
+	}
+
+	It("counts requests per route", func() {
+		server.do(http.MethodGet, "/api/v1/items", nil)
+		server.do(http.MethodGet, "/api/v1/items", nil)
+
+		body := scrape()
+		Expect(body).To(ContainSubstring(`http_requests_total{method="GET",route="/api/v1/items",status="401"} 2`))
+		Expect(body).To(ContainSubstring(`http_request_duration_seconds_count{method="GET",route="/api/v1/items"} 2`))
+	})
+
+	It("counts login results", func() {
+		server.login("administrator", "wrong-password")
+		server.loginAdmin()
+
+		body := scrape()
+		Expect(body).To(ContainSubstring(`auth_logins_total{result="failure"} 1`))
+		Expect(body).To(ContainSubstring(`auth_logins_total{result="success"} 1`))
+	})
+
+	It("exposes inventory gauges", func() {
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "ssd"}).Code).To(Equal(http.StatusCreated))
+		for _, item := range []map[string]any{
+			{"name": "SSD 256GB", "category_id": 1, "quantity": 10, "price": 30, "specification": "sata"},
+			{"name": "SSD 1TB", "category_id": 1, "quantity": 2, "price": 90, "specification": "nvme"},
+		} {
+			Expect(server.do(http.MethodPost, "/api/v1/items", item).Code).To(Equal(http.StatusCreated))
+		}
+
+		body := scrape()
+		Expect(body).To(ContainSubstring("inventory_items 2"))
+		Expect(body).To(ContainSubstring("inventory_stock_quantity 12"))
+		Expect(body).To(ContainSubstring("inventory_stock_value 480"))
+		Expect(body).To(ContainSubstring("inventory_items_below_reorder_point 1"))
+	})
+})
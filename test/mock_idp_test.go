@@ -0,0 +1,129 @@
+package test
+
+import (
+	"encoding/json"
+	"github.com/golang-jwt/jwt"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/oidc"
+	"inventory-management-system/signing"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	oidcClientID     = "inventory"
+	oidcClientSecret = "client-secret"
+	oidcRedirectURL  = "http://localhost/api/v1/oidc/callback"
+)
+
+type authorization struct {
+	challenge string
+	nonce     string
+	claims    jwt.MapClaims
+}
+
+type mockIdP struct {
+	server *httptest.Server
+	keys   signing.KeySet
+
+	mu      sync.Mutex
+	claims  jwt.MapClaims
+	codes   map[string]authorization
+	counter int
+}
+
+func newMockIdP() *mockIdP {
+	keys, err := signing.Generate()
+	Expect(err).NotTo(HaveOccurred())
+
+	idp := &mockIdP{keys: keys, codes: map[string]authorization{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, idp.keys.JWKS())
+	})
+	idp.server = httptest.NewServer(mux)
+
+	return idp
+}
+
+func (i *mockIdP) signInAs(claims jwt.MapClaims) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.claims = claims
+}
+
+func (i *mockIdP) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 i.server.URL,
+		"authorization_endpoint": i.server.URL + "/authorize",
+		"token_endpoint":         i.server.URL + "/token",
+		"jwks_uri":               i.server.URL + "/jwks",
+	})
+}
+
+func (i *mockIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != oidcClientID || query.Get("response_type") != "code" ||
+		query.Get("code_challenge_method") != "S256" || query.Get("redirect_uri") != oidcRedirectURL {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	i.mu.Lock()
+	i.counter++
+	code := "code-" + strconv.Itoa(i.counter)
+	i.codes[code] = authorization{challenge: query.Get("code_challenge"), nonce: query.Get("nonce"), claims: i.claims}
+	i.mu.Unlock()
+
+	redirect, _ := url.Parse(oidcRedirectURL)
+	redirect.RawQuery = url.Values{"code": {code}, "state": {query.Get("state")}}.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (i *mockIdP) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != oidcClientID || clientSecret != oidcClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	i.mu.Lock()
+	auth, found := i.codes[r.PostFormValue("code")]
+	delete(i.codes, r.PostFormValue("code"))
+	i.mu.Unlock()
+
+	if !found || r.PostFormValue("grant_type") != "authorization_code" ||
+		oidc.Challenge(r.PostFormValue("code_verifier")) != auth.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":   i.server.URL,
+		"aud":   []string{oidcClientID},
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"nonce": auth.nonce,
+	}
+	for name, value := range auth.claims {
+		claims[name] = value
+	}
+
+	idToken, err := i.keys.Sign(claims)
+	Expect(err).NotTo(HaveOccurred())
+	writeJSON(w, http.StatusOK, map[string]string{"id_token": idToken, "access_token": "opaque", "token_type": "Bearer"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	Expect(json.NewEncoder(w).Encode(v)).To(Succeed())
+}
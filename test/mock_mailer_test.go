@@ -0,0 +1,60 @@
+package test
+
+import (
+	"context"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/mail"
+	"sync"
+	"time"
+)
+
+type mockMailer struct {
+	mu       sync.Mutex
+	messages []mail.Message
+	deadline []time.Time
+	gate     chan struct{}
+}
+
+func (m *mockMailer) Send(ctx context.Context, message mail.Message) error {
+	m.mu.Lock()
+	gate := m.gate
+	m.mu.Unlock()
+	if gate != nil {
+		<-gate
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deadline, _ := ctx.Deadline()
+	m.messages = append(m.messages, message)
+	m.deadline = append(m.deadline, deadline)
+	return nil
+}
+
+func (m *mockMailer) hold() chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.gate = make(chan struct{})
+	return m.gate
+}
+
+func (m *mockMailer) sent() []mail.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]mail.Message(nil), m.messages...)
+}
+
+func (m *mockMailer) deadlines() []time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]time.Time(nil), m.deadline...)
+}
+
+func (m *mockMailer) delivered(count int) []mail.Message {
+	Eventually(m.sent).Should(HaveLen(count))
+	return m.sent()
+}
@@ -0,0 +1,214 @@
+package test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	s3Region    = "eu-central-1"
+	s3Bucket    = "inventory"
+	s3AccessKey = "AKIDEXAMPLE"
+	s3SecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+)
+
+type s3Object struct {
+	content     []byte
+	contentType string
+}
+
+type mockS3 struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	objects  map[string]s3Object
+	requests []string
+}
+
+func newMockS3() *mockS3 {
+	s3 := &mockS3{objects: map[string]s3Object{}}
+	s3.server = httptest.NewServer(http.HandlerFunc(s3.handle))
+	return s3
+}
+
+func (s *mockS3) handle(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := verifySignature(r, body); err != nil {
+		s3Error(w, http.StatusForbidden, "SignatureDoesNotMatch", err.Error())
+		return
+	}
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+s3Bucket+"/")
+	if !ok || key == "" {
+		s3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+key)
+	switch r.Method {
+	case http.MethodPut:
+		if r.ContentLength != int64(len(body)) {
+			s3Error(w, http.StatusBadRequest, "IncompleteBody", "content length "+strconv.FormatInt(r.ContentLength, 10)+" does not match the body")
+			return
+		}
+		s.objects[key] = s3Object{content: body, contentType: r.Header.Get("Content-Type")}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		object, ok := s.objects[key]
+		if !ok {
+			s3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist")
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		w.Write(object.content)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed")
+	}
+}
+
+func (s *mockS3) object(key string) (s3Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[key]
+	return object, ok
+}
+
+func (s *mockS3) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.requests)
+}
+
+func s3Error(w http.ResponseWriter, status int, code string, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, message)
+}
+
+func verifySignature(r *http.Request, body []byte) error {
+	authorization, ok := strings.CutPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+	if !ok {
+		return errors.New("missing AWS4-HMAC-SHA256 authorization")
+	}
+
+	fields := map[string]string{}
+	for _, field := range strings.Split(authorization, ", ") {
+		name, value, _ := strings.Cut(field, "=")
+		fields[name] = value
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	signedAt, err := time.Parse("20060102T150405Z", amzDate)
+	if err != nil {
+		return errors.New("invalid X-Amz-Date")
+	}
+	if time.Since(signedAt).Abs() > 5*time.Minute {
+		return errors.New("request time is too skewed")
+	}
+
+	scope := signedAt.Format("20060102") + "/" + s3Region + "/s3/aws4_request"
+	if fields["Credential"] != s3AccessKey+"/"+scope {
+		return errors.New("invalid credential " + fields["Credential"])
+	}
+
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash != "UNSIGNED-PAYLOAD" {
+		sum := sha256.Sum256(body)
+		if payloadHash != hex.EncodeToString(sum[:]) {
+			return errors.New("payload hash does not match")
+		}
+	}
+
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	required := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	if r.Method == http.MethodPut {
+		required = append(required, "content-type")
+	}
+	for _, name := range required {
+		if !slices.Contains(signedHeaders, name) {
+			return errors.New(name + " is not signed")
+		}
+	}
+	if !slices.IsSorted(signedHeaders) {
+		return errors.New("signed headers are not sorted")
+	}
+
+	canonicalHeaders := strings.Builder{}
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	query := r.URL.Query()
+	queryNames := make([]string, 0, len(query))
+	for name := range query {
+		queryNames = append(queryNames, name)
+	}
+	slices.Sort(queryNames)
+	canonicalQuery := []string{}
+	for _, name := range queryNames {
+		for _, value := range query[name] {
+			canonicalQuery = append(canonicalQuery, uriEncode(name)+"="+uriEncode(value))
+		}
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		strings.Join(canonicalQuery, "&"),
+		canonicalHeaders.String(),
+		fields["SignedHeaders"],
+		payloadHash,
+	}, "\n")
+	hashedRequest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashedRequest[:])
+
+	key := []byte("AWS4" + s3SecretKey)
+	for _, part := range []string{signedAt.Format("20060102"), s3Region, "s3", "aws4_request"} {
+		key = sign(key, part)
+	}
+	expected := hex.EncodeToString(sign(key, stringToSign))
+	if !hmac.Equal([]byte(expected), []byte(fields["Signature"])) {
+		return errors.New("signature does not match")
+	}
+
+	return nil
+}
+
+func uriEncode(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+func sign(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
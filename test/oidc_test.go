@@ -0,0 +1,236 @@
+package test
+
+import (
+	"github.com/golang-jwt/jwt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/config"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/signing"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var _ = Describe("OIDC single sign-on", func() {
+	var server *testServer
+
+	begin := func() (*url.URL, *http.Cookie) {
+		recorder := server.raw(http.MethodGet, "/api/v1/oidc/login")
+		Expect(recorder.Code).To(Equal(http.StatusFound))
+
+		var state *http.Cookie
+		for _, cookie := range recorder.Result().Cookies() {
+			if cookie.Name == "oidc_auth" {
+				state = cookie
+			}
+		}
+		Expect(state).NotTo(BeNil())
+		Expect(state.HttpOnly).To(BeTrue())
+		Expect(state.SameSite).To(Equal(http.SameSiteLaxMode))
+
+		location, err := url.Parse(recorder.Header().Get("Location"))
+		Expect(err).NotTo(HaveOccurred())
+		return location, state
+	}
+
+	authorize := func(location *url.URL) *url.URL {
+		client := idp.server.Client()
+		client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+		res, err := client.Get(location.String())
+		Expect(err).NotTo(HaveOccurred())
+		defer res.Body.Close()
+		Expect(res.StatusCode).To(Equal(http.StatusFound))
+
+		callback, err := url.Parse(res.Header.Get("Location"))
+		Expect(err).NotTo(HaveOccurred())
+		return callback
+	}
+
+	callback := func(callback *url.URL, state *http.Cookie) response {
+		res, _ := server.doWithHeaders(http.MethodGet, callback.RequestURI(), nil, http.Header{"Cookie": {state.Name + "=" + state.Value}})
+		return res
+	}
+
+	ssoLogin := func(claims jwt.MapClaims) response {
+		server.cookie = nil
+		server.csrf = nil
+		idp.signInAs(claims)
+
+		location, state := begin()
+		return callback(authorize(location), state)
+	}
+
+	user := func(username string) domain.Users {
+		var user domain.Users
+		Expect(server.connection.Where("username = ?", username).First(&user).Error).To(Succeed())
+		return user
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+	})
+
+	It("redirects to the identity provider with pkce", func() {
+		location, _ := begin()
+		Expect(location.String()).To(HavePrefix(idp.server.URL + "/authorize"))
+
+		query := location.Query()
+		Expect(query.Get("client_id")).To(Equal(oidcClientID))
+		Expect(query.Get("redirect_uri")).To(Equal(oidcRedirectURL))
+		Expect(query.Get("code_challenge_method")).To(Equal("S256"))
+		Expect(query.Get("code_challenge")).NotTo(BeEmpty())
+		Expect(query.Get("nonce")).NotTo(BeEmpty())
+		Expect(strings.Fields(query.Get("scope"))).To(ContainElement("openid"))
+	})
+
+	It("provisions a new user with the role mapped from its groups", func() {
+		res := ssoLogin(jwt.MapClaims{
+			"sub": "idp-1", "preferred_username": "jdoe", "name": "Jane Doe",
+			"email": "Jane@Example.com", "email_verified": true, "groups": []string{"staff", "inventory-admins"},
+		})
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(decode[domain.TokenPair](res).AccessToken).To(BeEmpty())
+		Expect(server.cookie.Value).NotTo(BeEmpty())
+
+		provisioned := user("jdoe")
+		Expect(provisioned.Role).To(Equal("admin"))
+		Expect(provisioned.FullName).To(Equal("Jane Doe"))
+		Expect(provisioned.Email).To(HaveValue(Equal("jane@example.com")))
+		Expect(*provisioned.OIDCSubject).To(Equal("idp-1"))
+
+		Expect(server.do(http.MethodGet, "/api/v1/users", nil).Code).To(Equal(http.StatusOK))
+		Expect(server.login("jdoe", "").Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("falls back to the default role and keeps the role in sync with the groups", func() {
+		claims := jwt.MapClaims{"sub": "idp-2", "preferred_username": "operator", "groups": []string{"staff"}}
+		Expect(ssoLogin(claims).Code).To(Equal(http.StatusOK))
+		Expect(user("operator").Role).To(Equal("user"))
+		Expect(server.do(http.MethodGet, "/api/v1/users", nil).Code).To(Equal(http.StatusUnauthorized))
+
+		claims["groups"] = []string{"inventory-admins"}
+		Expect(ssoLogin(claims).Code).To(Equal(http.StatusOK))
+		Expect(user("operator").Role).To(Equal("admin"))
+	})
+
+	It("links an existing local account by verified email", func() {
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/users", map[string]any{
+			"full_name": "Local User", "username": "localuser", "password": "Local-pass-7", "role": "user", "email": "local@example.com",
+		}).Code).To(Equal(http.StatusCreated))
+
+		res := ssoLogin(jwt.MapClaims{"sub": "idp-3", "preferred_username": "someone-else", "email": "LOCAL@example.com", "email_verified": true})
+		Expect(res.Code).To(Equal(http.StatusOK))
+
+		linked := user("localuser")
+		Expect(*linked.OIDCSubject).To(Equal("idp-3"))
+
+		var count int64
+		Expect(server.connection.Model(&domain.Users{}).Where("username = ?", "someone-else").Count(&count).Error).To(Succeed())
+		Expect(count).To(BeZero())
+
+		Expect(ssoLogin(jwt.MapClaims{"sub": "idp-3", "preferred_username": "renamed"}).Code).To(Equal(http.StatusOK))
+		Expect(server.login("localuser", "Local-pass-7").Code).To(Equal(http.StatusOK))
+	})
+
+	It("never links an existing local account by username", func() {
+		res := ssoLogin(jwt.MapClaims{"sub": "idp-4", "preferred_username": "administrator", "email": "nobody@example.com"})
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Problem.Code).To(Equal("USERNAME_TAKEN"))
+
+		admin := user("administrator")
+		Expect(admin.OIDCSubject).To(BeNil())
+		Expect(admin.Role).To(Equal("admin"))
+	})
+
+	It("refuses to link an account that belongs to another identity", func() {
+		claims := jwt.MapClaims{"sub": "idp-5", "preferred_username": "shared", "email": "shared@example.com", "email_verified": true}
+		Expect(ssoLogin(claims).Code).To(Equal(http.StatusOK))
+
+		claims["sub"] = "idp-6"
+		claims["preferred_username"] = "other"
+		res := ssoLogin(claims)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("account is linked to another identity"))
+	})
+
+	It("refuses a sign-in that would demote the last active admin", func() {
+		claims := jwt.MapClaims{"sub": "idp-10", "preferred_username": "ssoadmin", "groups": []string{"inventory-admins"}}
+		Expect(ssoLogin(claims).Code).To(Equal(http.StatusOK))
+		Expect(server.connection.Model(&domain.Users{}).Where("username = ?", "administrator").Update("active", false).Error).To(Succeed())
+
+		claims["groups"] = []string{"staff"}
+		res := ssoLogin(claims)
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Problem.Code).To(Equal("LAST_ACTIVE_ADMIN"))
+		Expect(user("ssoadmin").Role).To(Equal("admin"))
+	})
+
+	It("asks for the second factor of a user with two-factor authentication", func() {
+		claims := jwt.MapClaims{"sub": "idp-11", "preferred_username": "totpuser"}
+		Expect(ssoLogin(claims).Code).To(Equal(http.StatusOK))
+		Expect(server.connection.Model(&domain.Users{}).Where("username = ?", "totpuser").Update("totp_enabled", true).Error).To(Succeed())
+
+		res := ssoLogin(claims)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(res.Message).To(Equal("two-factor authentication required"))
+		Expect(server.cookie).To(BeNil())
+
+		mfa := decode[domain.MFAChallenge](res)
+		Expect(mfa.MFAToken).NotTo(BeEmpty())
+		Expect(mfa.EnrollmentRequired).To(BeFalse())
+	})
+
+	It("asks roles that require two-factor authentication to enroll", func() {
+		keys, err := signing.Generate()
+		Expect(err).NotTo(HaveOccurred())
+		server = newCustomTestServer(keys, config.MFA{RequiredRoles: []string{"admin"}})
+
+		res := ssoLogin(jwt.MapClaims{"sub": "idp-12", "preferred_username": "newadmin", "groups": []string{"inventory-admins"}})
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(res.Message).To(Equal("two-factor authentication required"))
+		Expect(server.cookie).To(BeNil())
+		Expect(decode[domain.MFAChallenge](res).EnrollmentRequired).To(BeTrue())
+	})
+
+	It("rejects a callback whose state does not match the cookie", func() {
+		idp.signInAs(jwt.MapClaims{"sub": "idp-7"})
+		location, _ := begin()
+		_, otherState := begin()
+
+		res := callback(authorize(location), otherState)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("invalid sso state"))
+	})
+
+	It("rejects a code redeemed with the wrong pkce verifier", func() {
+		idp.signInAs(jwt.MapClaims{"sub": "idp-8"})
+		location, state := begin()
+		parts := strings.Split(state.Value, ".")
+		state.Value = parts[0] + "." + parts[1] + ".wrong-verifier"
+
+		res := callback(authorize(location), state)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("sso login failed"))
+	})
+
+	It("rejects an id token with a different nonce", func() {
+		idp.signInAs(jwt.MapClaims{"sub": "idp-9", "nonce": "replayed"})
+		location, state := begin()
+
+		res := callback(authorize(location), state)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("sso login failed"))
+	})
+
+	It("reports errors returned by the identity provider", func() {
+		_, state := begin()
+		parts := strings.Split(state.Value, ".")
+
+		res := callback(&url.URL{Path: "/api/v1/oidc/callback", RawQuery: "error=access_denied&state=" + parts[0]}, state)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("sso login failed: access_denied"))
+	})
+})
@@ -0,0 +1,159 @@
+package test
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/mail"
+	"inventory-management-system/model/domain"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+var resetLinkPattern = regexp.MustCompile(`http://localhost/reset\?token=(\S+)`)
+
+var _ = Describe("Password reset", func() {
+	var server *testServer
+
+	forgot := func(email string) response {
+		return server.do(http.MethodPost, "/api/v1/password/forgot", map[string]any{"email": email})
+	}
+
+	reset := func(token string, newPassword string) response {
+		return server.do(http.MethodPost, "/api/v1/password/reset", map[string]any{"token": token, "new_password": newPassword})
+	}
+
+	lastToken := func(count int) string {
+		messages := server.mailer.delivered(count)
+		match := resetLinkPattern.FindStringSubmatch(messages[len(messages)-1].Body)
+		Expect(match).To(HaveLen(2))
+		token, err := url.QueryUnescape(match[1])
+		Expect(err).NotTo(HaveOccurred())
+		return token
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/users", map[string]any{
+			"full_name": "Jane Doe", "username": "janedoe", "password": "Jane-pass-7", "role": "user", "email": "jane@example.com",
+		}).Code).To(Equal(http.StatusCreated))
+		server.cookie = nil
+		server.csrf = nil
+	})
+
+	It("mails a single-use link that sets a new password", func() {
+		Expect(server.login("janedoe", "Jane-pass-7").Code).To(Equal(http.StatusOK))
+		tokens := server.tokens()
+
+		Expect(forgot("Jane@Example.com").Code).To(Equal(http.StatusOK))
+		messages := server.mailer.delivered(1)
+		Expect(messages[0].To).To(Equal("jane@example.com"))
+		Expect(messages[0].Subject).To(Equal("Reset your password"))
+		token := lastToken(1)
+
+		var stored domain.PasswordResets
+		Expect(server.connection.Where("username = ?", "janedoe").First(&stored).Error).To(Succeed())
+		Expect(stored.TokenHash).NotTo(Equal(token))
+
+		res := reset(token, "Fresh-pass-8")
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(res.Message).To(Equal("reset password success"))
+
+		res = reset(token, "Other-pass-9")
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("reset token is invalid or expired"))
+
+		Expect(server.do(http.MethodPost, "/api/v1/token/refresh", map[string]any{"refresh_token": tokens.RefreshToken}).Code).To(Equal(http.StatusUnauthorized))
+		res, _ = server.doWithHeaders(http.MethodGet, "/api/v1/me", nil, http.Header{"Authorization": {"Bearer " + tokens.AccessToken}})
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("token is revoked"))
+
+		Expect(server.login("janedoe", "Jane-pass-7").Code).To(Equal(http.StatusBadRequest))
+		Expect(server.login("janedoe", "Fresh-pass-8").Code).To(Equal(http.StatusOK))
+	})
+
+	It("answers the same for unknown emails without sending mail", func() {
+		res := forgot("nobody@example.com")
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(res.Message).To(Equal("if the email belongs to an account, a reset link has been sent"))
+		Consistently(server.mailer.sent, 50*time.Millisecond).Should(BeEmpty())
+
+		Expect(forgot("not-an-email").Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("answers before the link is mailed", func() {
+		gate := server.mailer.hold()
+
+		res := forgot("jane@example.com")
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(res.Message).To(Equal("if the email belongs to an account, a reset link has been sent"))
+		Expect(server.mailer.sent()).To(BeEmpty())
+
+		close(gate)
+		Expect(reset(lastToken(1), "Fresh-pass-8").Code).To(Equal(http.StatusOK))
+	})
+
+	It("invalidates earlier links when a new one is requested", func() {
+		Expect(forgot("jane@example.com").Code).To(Equal(http.StatusOK))
+		first := lastToken(1)
+		Expect(forgot("jane@example.com").Code).To(Equal(http.StatusOK))
+		second := lastToken(2)
+
+		Expect(reset(first, "Fresh-pass-8").Code).To(Equal(http.StatusBadRequest))
+		Expect(reset(second, "Fresh-pass-8").Code).To(Equal(http.StatusOK))
+	})
+
+	It("rejects expired and unknown tokens", func() {
+		Expect(forgot("jane@example.com").Code).To(Equal(http.StatusOK))
+		token := lastToken(1)
+
+		Expect(server.connection.Model(&domain.PasswordResets{}).Where("username = ?", "janedoe").
+			Update("expires_at", time.Now().Add(-time.Second)).Error).To(Succeed())
+
+		Expect(reset(token, "Fresh-pass-8").Code).To(Equal(http.StatusBadRequest))
+		Expect(reset("not-a-token", "Fresh-pass-8").Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("enforces the password policy and clears a lockout", func() {
+		for range loginMaxFailures {
+			server.login("janedoe", "wrong-password")
+		}
+		Expect(server.login("janedoe", "Jane-pass-7").Code).To(Equal(http.StatusBadRequest))
+
+		Expect(forgot("jane@example.com").Code).To(Equal(http.StatusOK))
+		token := lastToken(1)
+
+		res := reset(token, "password1")
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("password is too common"))
+
+		Expect(reset(token, "Fresh-pass-8").Code).To(Equal(http.StatusOK))
+		Expect(server.login("janedoe", "Fresh-pass-8").Code).To(Equal(http.StatusOK))
+	})
+
+	It("writes messages as eml files with the file mailer", func() {
+		dir := GinkgoT().TempDir()
+		mailer, err := mail.NewFileMailer(dir, "Inventory <no-reply@example.com>")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mailer.Send(context.Background(), mail.Message{To: "jane@example.com", Subject: "Hello", Body: "line one\nline two\n"})).To(Succeed())
+		Expect(mailer.Send(context.Background(), mail.Message{To: "jane@example.com\r\nBcc: x@example.com", Subject: "Hello"})).NotTo(Succeed())
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+
+		content, err := os.ReadFile(files[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("From: Inventory <no-reply@example.com>\r\n"))
+		Expect(string(content)).To(ContainSubstring("To: jane@example.com\r\n"))
+		Expect(string(content)).To(ContainSubstring("Subject: Hello\r\n"))
+		Expect(string(content)).To(ContainSubstring("@example.com>\r\n"))
+		Expect(string(content)).To(HaveSuffix("\r\n\r\nline one\r\nline two\r\n"))
+	})
+})
@@ -0,0 +1,105 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/model/domain"
+	"net/http"
+)
+
+var _ = Describe("Profile", func() {
+	var server *testServer
+	var tokens domain.TokenPair
+
+	changePassword := func(currentPassword string, newPassword string) response {
+		return server.do(http.MethodPost, "/api/v1/me/password", map[string]any{
+			"current_password": currentPassword, "new_password": newPassword,
+		})
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/users", map[string]any{
+			"full_name": "Jane Doe", "username": "janedoe", "password": "Jane-pass-7", "role": "user",
+		}).Code).To(Equal(http.StatusCreated))
+
+		res := server.login("janedoe", "Jane-pass-7")
+		Expect(res.Code).To(Equal(http.StatusOK))
+		tokens = server.tokens()
+	})
+
+	It("lets a regular user read and update their own profile", func() {
+		res := server.do(http.MethodGet, "/api/v1/me", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		user := decode[domain.Users](res)
+		Expect(user.Username).To(Equal("janedoe"))
+		Expect(user.Password).To(Equal("-"))
+
+		res = server.do(http.MethodPut, "/api/v1/me", map[string]any{"full_name": "Jane Q. Doe"})
+		Expect(res.Code).To(Equal(http.StatusOK))
+
+		user = decode[domain.Users](server.do(http.MethodGet, "/api/v1/me", nil))
+		Expect(user.FullName).To(Equal("Jane Q. Doe"))
+		Expect(user.Role).To(Equal("user"))
+
+		Expect(server.do(http.MethodPut, "/api/v1/me", map[string]any{"full_name": ""}).Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("requires a session", func() {
+		server.cookie = nil
+		Expect(server.do(http.MethodGet, "/api/v1/me", nil).Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("requires the current password", func() {
+		res := changePassword("wrong-password", "Fresh-pass-8")
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("current password is incorrect"))
+
+		res = changePassword("Jane-pass-7", "Jane-pass-7")
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("new password must be different from the current password"))
+	})
+
+	It("enforces the password policy", func() {
+		res := changePassword("Jane-pass-7", "Ab1!")
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("password must be at least 8 characters"))
+
+		res = changePassword("Jane-pass-7", "onlyletters")
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(ContainSubstring("password must mix at least 2"))
+
+		res = changePassword("Jane-pass-7", "P@ssw0rd")
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("password is too common"))
+
+		server.loginAdmin()
+		res = server.do(http.MethodPost, "/api/v1/users", map[string]any{
+			"full_name": "Weak", "username": "weakling", "password": "password1", "role": "user",
+		})
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("password is too common"))
+	})
+
+	It("revokes existing sessions and issues new tokens on change", func() {
+		res := changePassword("Jane-pass-7", "Fresh-pass-8")
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(decode[domain.TokenPair](res).AccessToken).To(BeEmpty())
+		fresh := server.tokens()
+		Expect(fresh.AccessToken).NotTo(Equal(tokens.AccessToken))
+
+		res = server.do(http.MethodPost, "/api/v1/token/refresh", map[string]any{"refresh_token": tokens.RefreshToken})
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("invalid refresh token"))
+
+		res, _ = server.doWithHeaders(http.MethodGet, "/api/v1/me", nil, http.Header{"Authorization": {"Bearer " + tokens.AccessToken}})
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("token is revoked"))
+
+		Expect(server.do(http.MethodPost, "/api/v1/token/refresh", map[string]any{"refresh_token": fresh.RefreshToken}).Code).To(Equal(http.StatusOK))
+
+		Expect(server.login("janedoe", "Jane-pass-7").Code).To(Equal(http.StatusBadRequest))
+		Expect(server.login("janedoe", "Fresh-pass-8").Code).To(Equal(http.StatusOK))
+	})
+})
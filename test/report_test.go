@@ -0,0 +1,99 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/model/domain"
+	"net/http"
+)
+
+var _ = Describe("Reports", func() {
+	var server *testServer
+
+	addItem := func(name string, quantity int, price float64) {
+		res := server.do(http.MethodPost, "/api/v1/items", map[string]any{
+			"name":          name,
+			"category_id":   1,
+			"quantity":      quantity,
+			"price":         price,
+			"specification": name,
+		})
+		Expect(res.Code).To(Equal(http.StatusCreated))
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "monitor"}).Code).To(Equal(http.StatusCreated))
+	})
+
+	It("has no activity before anything changes", func() {
+		res := server.do(http.MethodGet, "/api/v1/reports/activity", nil)
+		Expect(res.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("logs every item change", func() {
+		addItem("Monitor 24", 10, 150)
+		res := server.do(http.MethodPut, "/api/v1/items/1", map[string]any{
+			"name":          "Monitor 24",
+			"category_id":   1,
+			"quantity":      7,
+			"price":         150,
+			"specification": "Monitor 24",
+		})
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(server.do(http.MethodDelete, "/api/v1/items/1", nil).Code).To(Equal(http.StatusOK))
+
+		res = server.do(http.MethodGet, "/api/v1/reports/activity", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		activities := decode[[]domain.Activities](res)
+		Expect(activities).To(HaveLen(3))
+		Expect(activities[0].Action).To(Equal(domain.ActionPost))
+		Expect(activities[0].QuantityChange).To(Equal(10))
+		Expect(activities[1].Action).To(Equal(domain.ActionUpdate))
+		Expect(activities[1].QuantityChange).To(Equal(-3))
+		Expect(activities[2].Action).To(Equal(domain.ActionDelete))
+		Expect(activities[2].QuantityChange).To(Equal(0))
+		for _, activity := range activities {
+			Expect(activity.ItemID).To(Equal(1))
+			Expect(activity.PerformedBy).To(Equal("administrator"))
+		}
+	})
+
+	It("does not log a rejected change", func() {
+		addItem("Monitor 24", 10, 150)
+		res := server.do(http.MethodPost, "/api/v1/items", map[string]any{
+			"name":          "Monitor 24",
+			"category_id":   1,
+			"quantity":      1,
+			"price":         150,
+			"specification": "Monitor 24",
+		})
+		Expect(res.Code).To(Equal(http.StatusConflict))
+
+		activities := decode[[]domain.Activities](server.do(http.MethodGet, "/api/v1/reports/activity", nil))
+		Expect(activities).To(HaveLen(1))
+	})
+
+	It("reports items at or below the stock threshold", func() {
+		addItem("Monitor 24", 10, 150)
+		addItem("Monitor 27", 3, 250)
+		addItem("Monitor 32", 5, 400)
+
+		res := server.do(http.MethodGet, "/api/v1/reports/stock/5", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		report := decode[domain.ReportStock](res)
+		Expect(report.TotalItems).To(Equal(2))
+		Expect(report.TotalQuantity).To(Equal(8))
+		Expect(report.TotalInventoryValue).To(Equal(650.0))
+		Expect(report.Items).To(HaveLen(2))
+
+		res = server.do(http.MethodGet, "/api/v1/reports/stock/1", nil)
+		Expect(res.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("rejects a non numeric threshold", func() {
+		res := server.do(http.MethodGet, "/api/v1/reports/stock/many", nil)
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+	})
+})
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"github.com/golang-jwt/jwt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/config"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/signing"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var _ = Describe("Token signing", func() {
+	bearer := func(server *testServer, token string) response {
+		server.cookie = nil
+		server.csrf = nil
+		res, _ := server.doWithHeaders(http.MethodGet, "/api/v1/users", nil, http.Header{"Authorization": {"Bearer " + token}})
+		return res
+	}
+
+	claims := func(expiresAt time.Time) *domain.JwtCustomClaims {
+		return &domain.JwtCustomClaims{
+			Username:       "administrator",
+			Role:           "admin",
+			StandardClaims: jwt.StandardClaims{IssuedAt: time.Now().Unix(), ExpiresAt: expiresAt.Unix()},
+		}
+	}
+
+	withSession := func(server *testServer, token string) *testServer {
+		session := domain.Sessions{Username: "administrator", Token: token, ExpiresAt: time.Now().Add(time.Minute), RefreshExpiresAt: time.Now().Add(time.Minute)}
+		Expect(server.connection.Create(&session).Error).To(Succeed())
+		return server
+	}
+
+	It("signs access tokens with a kid published in the jwks", func() {
+		server := newTestServer()
+		Expect(server.login("administrator", adminPassword).Code).To(Equal(http.StatusOK))
+		tokens := server.tokens()
+
+		header := strings.Split(tokens.AccessToken, ".")[0]
+		decoded, err := jwt.DecodeSegment(header)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(decoded)).To(ContainSubstring(`"alg":"EdDSA"`))
+		Expect(string(decoded)).To(ContainSubstring(`"kid":"` + server.keys.KeyID() + `"`))
+
+		recorder := server.raw(http.MethodGet, "/.well-known/jwks.json")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		var jwks signing.JWKS
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &jwks)).To(Succeed())
+		Expect(jwks.Keys).To(HaveLen(1))
+		Expect(jwks.Keys[0].Kid).To(Equal(server.keys.KeyID()))
+		Expect(jwks.Keys[0].Kty).To(Equal("OKP"))
+		Expect(jwks.Keys[0].Alg).To(Equal("EdDSA"))
+	})
+
+	It("signs with RS256 when given an rsa key", func() {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		keys, err := signing.New(privateKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		server := newCustomTestServer(keys, config.MFA{})
+		Expect(server.login("administrator", adminPassword).Code).To(Equal(http.StatusOK))
+		tokens := server.tokens()
+		Expect(bearer(server, tokens.AccessToken).Code).To(Equal(http.StatusOK))
+
+		Expect(keys.JWKS().Keys[0].Alg).To(Equal("RS256"))
+		Expect(keys.JWKS().Keys[0].N).NotTo(BeEmpty())
+	})
+
+	It("keeps accepting tokens from a rotated out key", func() {
+		_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		_, newKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+
+		oldKeys, err := signing.New(oldKey)
+		Expect(err).NotTo(HaveOccurred())
+		token, err := oldKeys.Sign(claims(time.Now().Add(time.Minute)))
+		Expect(err).NotTo(HaveOccurred())
+
+		rotated, err := signing.New(newKey, oldKey.Public())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rotated.JWKS().Keys).To(HaveLen(2))
+		Expect(bearer(withSession(newCustomTestServer(rotated, config.MFA{}), token), token).Code).To(Equal(http.StatusOK))
+
+		withoutOld, err := signing.New(newKey)
+		Expect(err).NotTo(HaveOccurred())
+		res := bearer(withSession(newCustomTestServer(withoutOld, config.MFA{}), token), token)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("token is invalid"))
+	})
+
+	It("answers 401 for expired and malformed tokens", func() {
+		server := newTestServer()
+
+		expired, err := server.keys.Sign(claims(time.Now().Add(-time.Minute)))
+		Expect(err).NotTo(HaveOccurred())
+		res := bearer(server, expired)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("token is expired"))
+
+		Expect(bearer(server, "not-a-token").Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects a correctly signed token that has no session", func() {
+		server := newTestServer()
+		token, err := server.keys.Sign(claims(time.Now().Add(time.Minute)))
+		Expect(err).NotTo(HaveOccurred())
+
+		res := bearer(server, token)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("token is invalid"))
+	})
+
+	It("rejects tokens signed with the legacy shared secret", func() {
+		server := newTestServer()
+
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims(time.Now().Add(time.Minute)))
+		token.Header["kid"] = server.keys.KeyID()
+		signed, err := token.SignedString([]byte("secret-key"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(bearer(server, signed).Code).To(Equal(http.StatusUnauthorized))
+	})
+})
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/storage"
+	"io"
+	"strings"
+)
+
+var _ = Describe("S3 storage", func() {
+	ctx := context.Background()
+
+	var (
+		s3          *mockS3
+		fileStorage storage.Storage
+	)
+
+	put := func(key string, content string, contentType string) error {
+		return fileStorage.Put(ctx, key, strings.NewReader(content), int64(len(content)), contentType)
+	}
+
+	BeforeEach(func() {
+		s3 = newMockS3()
+		DeferCleanup(s3.server.Close)
+
+		var err error
+		fileStorage, err = storage.NewS3Storage(s3.server.URL, s3Region, s3Bucket, s3AccessKey, s3SecretKey, s3.server.Client())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("puts, gets and deletes signed objects", func() {
+		Expect(put("items/1/datasheet v2.pdf", "%PDF-1.4 datasheet", "application/pdf")).To(Succeed())
+		object, ok := s3.object("items/1/datasheet v2.pdf")
+		Expect(ok).To(BeTrue())
+		Expect(object.contentType).To(Equal("application/pdf"))
+
+		content, err := fileStorage.Get(ctx, "items/1/datasheet v2.pdf")
+		Expect(err).NotTo(HaveOccurred())
+		data, err := io.ReadAll(content)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content.Close()).To(Succeed())
+		Expect(string(data)).To(Equal("%PDF-1.4 datasheet"))
+
+		Expect(fileStorage.Delete(ctx, "items/1/datasheet v2.pdf")).To(Succeed())
+		_, ok = s3.object("items/1/datasheet v2.pdf")
+		Expect(ok).To(BeFalse())
+
+		Expect(s3.received()).To(Equal([]string{
+			"PUT items/1/datasheet v2.pdf",
+			"GET items/1/datasheet v2.pdf",
+			"DELETE items/1/datasheet v2.pdf",
+		}))
+	})
+
+	It("reports missing objects as not found", func() {
+		_, err := fileStorage.Get(ctx, "items/1/missing.png")
+		Expect(err).To(MatchError(storage.ErrNotFound))
+
+		Expect(fileStorage.Delete(ctx, "items/1/missing.png")).To(Succeed())
+	})
+
+	It("surfaces requests the server refuses", func() {
+		var err error
+		fileStorage, err = storage.NewS3Storage(s3.server.URL, s3Region, s3Bucket, s3AccessKey, "wrong-secret", s3.server.Client())
+		Expect(err).NotTo(HaveOccurred())
+
+		err = put("items/1/photo.png", "png", "image/png")
+		Expect(err).To(MatchError(ContainSubstring("403 Forbidden")))
+		Expect(err).To(MatchError(ContainSubstring("SignatureDoesNotMatch")))
+		_, ok := s3.object("items/1/photo.png")
+		Expect(ok).To(BeFalse())
+
+		_, err = fileStorage.Get(ctx, "items/1/photo.png")
+		Expect(err).To(HaveOccurred())
+		Expect(err).NotTo(MatchError(storage.ErrNotFound))
+	})
+
+	It("requires an endpoint and a bucket", func() {
+		_, err := storage.NewS3Storage("", s3Region, s3Bucket, s3AccessKey, s3SecretKey, nil)
+		Expect(err).To(HaveOccurred())
+		_, err = storage.NewS3Storage(s3.server.URL, s3Region, "", s3AccessKey, s3SecretKey, nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
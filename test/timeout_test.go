@@ -0,0 +1,73 @@
+package test
+
+import (
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/app"
+	"inventory-management-system/config"
+	"inventory-management-system/controller"
+	"inventory-management-system/helper"
+	"inventory-management-system/i18n"
+	"inventory-management-system/middleware"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/repository"
+	"inventory-management-system/service"
+	"net/http"
+	"time"
+)
+
+var _ = Describe("Request timeouts", func() {
+	var server *testServer
+
+	BeforeEach(func() {
+		translator, err := i18n.New(helper.NewValidator())
+		Expect(err).NotTo(HaveOccurred())
+
+		engine := gin.New()
+		engine.Use(middleware.Locale(translator))
+		server = &testServer{engine: engine}
+	})
+
+	It("answers 504 when the handler gives up at the deadline", func() {
+		server.engine.GET("/slow", middleware.Timeout(10*time.Millisecond), func(ctx *gin.Context) {
+			<-ctx.Request.Context().Done()
+		})
+		server.engine.GET("/fast", middleware.Timeout(time.Second), func(ctx *gin.Context) {
+			ctx.String(http.StatusOK, "done")
+		})
+
+		res := server.do(http.MethodGet, "/slow", nil)
+		Expect(res.Code).To(Equal(http.StatusGatewayTimeout))
+		Expect(res.Problem.Code).To(Equal("DEADLINE_EXCEEDED"))
+		Expect(res.Message).To(Equal("request deadline exceeded"))
+
+		res, _ = server.doWithHeaders(http.MethodGet, "/slow", nil, http.Header{"Accept-Language": {"id"}})
+		Expect(res.Message).To(Equal("batas waktu permintaan terlampaui"))
+
+		recorder := server.fetch(http.MethodGet, "/fast", nil, "")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(Equal("done"))
+	})
+
+	It("maps a deadline hit in the service layer to 504", func() {
+		connection, err := app.NewSQLite(":memory:").Connect()
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() {
+			Expect(app.Close(connection)).To(Succeed())
+		})
+		Expect(connection.AutoMigrate(domain.Activities{}, domain.Items{})).To(Succeed())
+
+		reportService := service.NewReportService(repository.NewActivityRepository(connection), repository.NewItemRepository(connection))
+		authenticate := func(ctx *gin.Context) {
+			ctx.Set("username", "administrator")
+			ctx.Set("role", "admin")
+			ctx.Next()
+		}
+		app.ReportRouter(server.engine, controller.NewReportController(reportService), authenticate, config.Timeouts{Report: time.Nanosecond})
+
+		res := server.do(http.MethodGet, "/api/v1/reports/activity", nil)
+		Expect(res.Code).To(Equal(http.StatusGatewayTimeout))
+		Expect(res.Problem.Code).To(Equal("DEADLINE_EXCEEDED"))
+	})
+})
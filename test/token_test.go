@@ -0,0 +1,204 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/config"
+	"inventory-management-system/model/domain"
+	"net/http"
+	"time"
+)
+
+var _ = Describe("Tokens", func() {
+	var server *testServer
+	var tokens domain.TokenPair
+	var body domain.TokenPair
+
+	refresh := func(refreshToken string) response {
+		return server.do(http.MethodPost, "/api/v1/token/refresh", map[string]any{"refresh_token": refreshToken})
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		res := server.login("administrator", adminPassword)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		tokens = server.tokens()
+		body = decode[domain.TokenPair](res)
+	})
+
+	It("issues a short lived access token and a refresh token on login", func() {
+		Expect(tokens.AccessToken).NotTo(BeEmpty())
+		Expect(tokens.RefreshToken).NotTo(BeEmpty())
+		Expect(server.refresh.HttpOnly).To(BeTrue())
+		Expect(server.refresh.Path).To(Equal("/api/v1/token"))
+
+		Expect(body.TokenType).To(Equal("Bearer"))
+		Expect(body.ExpiresAt).To(BeTemporally("~", time.Now().Add(accessTokenTTL), 5*time.Second))
+		Expect(body.RefreshExpiresAt).To(BeTemporally("~", time.Now().Add(refreshTokenTTL), 5*time.Second))
+	})
+
+	It("keeps the tokens out of the body when they are set as cookies", func() {
+		Expect(body.AccessToken).To(BeEmpty())
+		Expect(body.RefreshToken).To(BeEmpty())
+		Expect(body.CSRFToken).To(Equal(server.csrf.Value))
+
+		res := server.do(http.MethodPost, "/api/v1/token/refresh", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(string(res.Data)).NotTo(ContainSubstring(server.tokens().AccessToken))
+		Expect(string(res.Data)).NotTo(ContainSubstring(server.tokens().RefreshToken))
+		Expect(string(res.Data)).NotTo(ContainSubstring("access_token"))
+	})
+
+	It("returns the tokens in the body when cookies are disabled", func() {
+		server = newTestServerWithCookies(config.Cookie{})
+		res := server.login("administrator", adminPassword)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(server.cookie).To(BeNil())
+		Expect(server.refresh).To(BeNil())
+		Expect(server.csrf).To(BeNil())
+
+		tokens = decode[domain.TokenPair](res)
+		Expect(tokens.AccessToken).NotTo(BeEmpty())
+		Expect(tokens.CSRFToken).To(BeEmpty())
+		res, _ = server.doWithHeaders(http.MethodPost, "/api/v1/category", map[string]any{"name": "ram"}, http.Header{"Authorization": {"Bearer " + tokens.AccessToken}})
+		Expect(res.Code).To(Equal(http.StatusCreated))
+
+		res = refresh(tokens.RefreshToken)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(decode[domain.TokenPair](res).RefreshToken).NotTo(BeEmpty())
+		Expect(server.refresh).To(BeNil())
+	})
+
+	It("rotates the refresh token", func() {
+		res := refresh(tokens.RefreshToken)
+		Expect(res.Code).To(Equal(http.StatusOK))
+
+		rotated := decode[domain.TokenPair](res)
+		Expect(rotated.RefreshToken).NotTo(Equal(tokens.RefreshToken))
+		Expect(rotated.AccessToken).NotTo(Equal(tokens.AccessToken))
+		res, _ = server.doWithHeaders(http.MethodGet, "/api/v1/users", nil, http.Header{"Authorization": {"Bearer " + rotated.AccessToken}})
+		Expect(res.Code).To(Equal(http.StatusOK))
+
+		Expect(refresh(rotated.RefreshToken).Code).To(Equal(http.StatusOK))
+	})
+
+	It("returns the tokens in the body when the client asks for them", func() {
+		server = newTestServer()
+		res := server.do(http.MethodPost, "/api/v1/login", map[string]any{"username": "administrator", "password": adminPassword, "token_delivery": "body"})
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(server.cookie).To(BeNil())
+		Expect(server.refresh).To(BeNil())
+
+		tokens = decode[domain.TokenPair](res)
+		Expect(tokens.AccessToken).NotTo(BeEmpty())
+		Expect(tokens.RefreshToken).NotTo(BeEmpty())
+		Expect(tokens.CSRFToken).To(BeEmpty())
+
+		bearer := http.Header{"Authorization": {"Bearer " + tokens.AccessToken}}
+		res, _ = server.doWithHeaders(http.MethodPost, "/api/v1/category", map[string]any{"name": "ram"}, bearer)
+		Expect(res.Code).To(Equal(http.StatusCreated))
+
+		res = refresh(tokens.RefreshToken)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(decode[domain.TokenPair](res).RefreshToken).NotTo(BeEmpty())
+		Expect(server.cookie).To(BeNil())
+
+		res, _ = server.doWithHeaders(http.MethodPost, "/api/v1/me/password", map[string]any{"current_password": adminPassword, "new_password": "Fresh-pass-8"},
+			http.Header{"Authorization": {"Bearer " + decode[domain.TokenPair](res).AccessToken}})
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(decode[domain.TokenPair](res).AccessToken).NotTo(BeEmpty())
+		Expect(server.cookie).To(BeNil())
+
+		Expect(server.do(http.MethodPost, "/api/v1/login", map[string]any{"username": "administrator", "password": "Fresh-pass-8", "token_delivery": "email"}).Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("accepts the refresh token from its cookie", func() {
+		res := server.do(http.MethodPost, "/api/v1/token/refresh", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(server.tokens().RefreshToken).NotTo(Equal(tokens.RefreshToken))
+	})
+
+	It("revokes the whole family when a refresh token is reused", func() {
+		res := refresh(tokens.RefreshToken)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		rotated := decode[domain.TokenPair](res)
+
+		res = refresh(tokens.RefreshToken)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("refresh token reuse detected"))
+
+		res = refresh(rotated.RefreshToken)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("invalid refresh token"))
+
+		for _, accessToken := range []string{tokens.AccessToken, rotated.AccessToken} {
+			res, _ = server.doWithHeaders(http.MethodGet, "/api/v1/users", nil, http.Header{"Authorization": {"Bearer " + accessToken}})
+			Expect(res.Code).To(Equal(http.StatusUnauthorized))
+			Expect(res.Message).To(Equal("token is revoked"))
+		}
+	})
+
+	It("rejects an expired refresh token", func() {
+		err := server.connection.Model(&domain.Sessions{}).Where("username = ?", "administrator").
+			Update("refresh_expires_at", time.Now().Add(-time.Second)).Error
+		Expect(err).NotTo(HaveOccurred())
+
+		res := refresh(tokens.RefreshToken)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("refresh token expired"))
+	})
+
+	It("rejects unknown and missing refresh tokens", func() {
+		Expect(refresh("not-a-token").Code).To(Equal(http.StatusUnauthorized))
+
+		server.refresh = nil
+		res := server.do(http.MethodPost, "/api/v1/token/refresh", nil)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("refresh token is empty"))
+	})
+
+	It("logs out every token of the login and clears the cookies", func() {
+		res := refresh(tokens.RefreshToken)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		rotated := decode[domain.TokenPair](res)
+
+		res, headers := server.doWithHeaders(http.MethodPost, "/api/v1/logout", nil, nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		cleared := map[string]bool{}
+		for _, cookie := range (&http.Response{Header: headers}).Cookies() {
+			Expect(cookie.Value).To(BeEmpty())
+			Expect(cookie.Expires).To(BeTemporally("<", time.Now()))
+			cleared[cookie.Name] = true
+		}
+		Expect(cleared).To(Equal(map[string]bool{"session_token": true, "refresh_token": true, "csrf_token": true}))
+
+		res = server.do(http.MethodGet, "/api/v1/users", nil)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("session token is empty"))
+
+		for _, accessToken := range []string{tokens.AccessToken, rotated.AccessToken} {
+			res, _ = server.doWithHeaders(http.MethodGet, "/api/v1/users", nil, http.Header{"Authorization": {"Bearer " + accessToken}})
+			Expect(res.Code).To(Equal(http.StatusUnauthorized))
+			Expect(res.Message).To(Equal("token is revoked"))
+		}
+		Expect(refresh(rotated.RefreshToken).Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("keeps other logins of the same user", func() {
+		Expect(server.login("administrator", adminPassword).Code).To(Equal(http.StatusOK))
+		other := server.tokens()
+		server.cookie = nil
+		server.refresh = nil
+		server.csrf = nil
+
+		bearer := http.Header{"Authorization": {"Bearer " + tokens.AccessToken}}
+		res, _ := server.doWithHeaders(http.MethodPost, "/api/v1/logout", nil, bearer)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		res, _ = server.doWithHeaders(http.MethodPost, "/api/v1/logout", nil, bearer)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+
+		res, _ = server.doWithHeaders(http.MethodGet, "/api/v1/users", nil, http.Header{"Authorization": {"Bearer " + other.AccessToken}})
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(refresh(other.RefreshToken).Code).To(Equal(http.StatusOK))
+	})
+})
@@ -0,0 +1,91 @@
+package test
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"inventory-management-system/tracing"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Tracing", func() {
+	const (
+		traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentSpanID = "00f067aa0ba902b7"
+	)
+
+	var server *testServer
+
+	spansOf := func(id string) []sdktrace.ReadOnlySpan {
+		var spans []sdktrace.ReadOnlySpan
+		for _, span := range spanRecorder.Ended() {
+			if span.SpanContext().TraceID().String() == id {
+				spans = append(spans, span)
+			}
+		}
+		return spans
+	}
+
+	named := func(spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+		for _, span := range spans {
+			if span.Name() == name {
+				return span
+			}
+		}
+		Fail("no span named " + name)
+		return nil
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "gpu"}).Code).To(Equal(http.StatusCreated))
+	})
+
+	It("continues an inbound trace through services and queries", func() {
+		res, _ := server.doWithHeaders(http.MethodPost, "/api/v1/items", map[string]any{
+			"name":          "RTX 4060",
+			"category_id":   1,
+			"quantity":      2,
+			"price":         300,
+			"specification": "8GB",
+		}, http.Header{"traceparent": {"00-" + traceID + "-" + parentSpanID + "-01"}})
+		Expect(res.Code).To(Equal(http.StatusCreated))
+
+		spans := spansOf(traceID)
+		request := named(spans, "POST /api/v1/items")
+		Expect(request.Parent().SpanID().String()).To(Equal(parentSpanID))
+		Expect(request.SpanKind()).To(Equal(trace.SpanKindServer))
+
+		add := named(spans, "ItemService.Add")
+		Expect(add.Parent().SpanID()).To(Equal(request.SpanContext().SpanID()))
+
+		create := named(spans, "gorm.Create")
+		Expect(create.Parent().SpanID()).To(Equal(add.SpanContext().SpanID()))
+		Expect(create.Attributes()).To(ContainElement(HaveField("Key", BeEquivalentTo("db.query.text"))))
+	})
+
+	It("propagates the trace to outbound calls", func() {
+		var traceparent string
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceparent = r.Header.Get("traceparent")
+		}))
+		DeferCleanup(upstream.Close)
+
+		ctx, span := tracing.Start(context.Background(), "outbound")
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, upstream.URL, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		client := &http.Client{Transport: tracing.NewTransport(nil)}
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		span.End()
+
+		Expect(traceparent).To(ContainSubstring(span.SpanContext().TraceID().String()))
+		named(spansOf(span.SpanContext().TraceID().String()), "HTTP GET")
+	})
+})
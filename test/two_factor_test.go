@@ -0,0 +1,181 @@
+package test
+
+import (
+	"encoding/base32"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/config"
+	"inventory-management-system/model/domain"
+	"inventory-management-system/signing"
+	"inventory-management-system/totp"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var _ = Describe("Two-factor authentication", func() {
+	var server *testServer
+
+	code := func(secret string, offset int64) string {
+		code, err := totp.Code(secret, totp.Step(time.Now())+offset)
+		Expect(err).NotTo(HaveOccurred())
+		return code
+	}
+
+	enroll := func() (domain.TOTPEnrollment, []string) {
+		res := server.do(http.MethodPost, "/api/v1/mfa/totp", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		enrollment := decode[domain.TOTPEnrollment](res)
+
+		res = server.do(http.MethodPost, "/api/v1/mfa/totp/confirm", map[string]any{"code": code(enrollment.Secret, 0)})
+		Expect(res.Code).To(Equal(http.StatusOK))
+		return enrollment, decode[map[string][]string](res)["recovery_codes"]
+	}
+
+	challenge := func(username string, password string) domain.MFAChallenge {
+		res := server.login(username, password)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(res.Message).To(Equal("two-factor authentication required"))
+		Expect(server.cookie).To(BeNil())
+		return decode[domain.MFAChallenge](res)
+	}
+
+	verify := func(mfaToken string, code string) response {
+		return server.do(http.MethodPost, "/api/v1/login/mfa", map[string]any{"mfa_token": mfaToken, "code": code})
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+	})
+
+	It("generates rfc 6238 codes", func() {
+		secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+		Expect(totp.Code(secret, 59/30)).To(Equal("287082"))
+		Expect(totp.Code(secret, 1111111109/30)).To(Equal("081804"))
+	})
+
+	It("enrolls with a provisioning uri and qr code and returns recovery codes once", func() {
+		enrollment, recoveryCodes := enroll()
+		Expect(enrollment.ProvisioningURI).To(HavePrefix("otpauth://totp/Inventory:administrator?"))
+		Expect(enrollment.ProvisioningURI).To(ContainSubstring("secret=" + enrollment.Secret))
+		Expect(enrollment.QRCode).To(HavePrefix("data:image/png;base64,"))
+		Expect(recoveryCodes).To(HaveLen(10))
+
+		user := decode[map[string]any](server.do(http.MethodGet, "/api/v1/users/administrator", nil))
+		Expect(user["totp_enabled"]).To(BeTrue())
+		Expect(user).NotTo(HaveKey("totp_secret"))
+		Expect(user).NotTo(HaveKey("recovery_codes"))
+
+		Expect(server.do(http.MethodPost, "/api/v1/mfa/totp", nil).Code).To(Equal(http.StatusConflict))
+	})
+
+	It("rejects a wrong code during enrollment", func() {
+		res := server.do(http.MethodPost, "/api/v1/mfa/totp", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+
+		res = server.do(http.MethodPost, "/api/v1/mfa/totp/confirm", map[string]any{"code": "000000"})
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("invalid two-factor code"))
+	})
+
+	It("issues the session only after a valid code", func() {
+		enrollment, _ := enroll()
+		mfa := challenge("administrator", adminPassword)
+		Expect(mfa.EnrollmentRequired).To(BeFalse())
+
+		res := verify(mfa.MFAToken, "000000")
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("invalid two-factor code"))
+
+		Expect(verify(mfa.MFAToken, code(enrollment.Secret, 0)).Code).To(Equal(http.StatusUnauthorized))
+
+		res = verify(mfa.MFAToken, code(enrollment.Secret, 1))
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(server.cookie).NotTo(BeNil())
+		Expect(server.do(http.MethodGet, "/api/v1/users", nil).Code).To(Equal(http.StatusOK))
+	})
+
+	It("does not accept the mfa token as an access token", func() {
+		enroll()
+		mfa := challenge("administrator", adminPassword)
+
+		res, _ := server.doWithHeaders(http.MethodGet, "/api/v1/users", nil, http.Header{"Authorization": {"Bearer " + mfa.MFAToken}})
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("accepts each recovery code once", func() {
+		_, recoveryCodes := enroll()
+
+		mfa := challenge("administrator", adminPassword)
+		Expect(verify(mfa.MFAToken, strings.ToUpper(recoveryCodes[0])).Code).To(Equal(http.StatusOK))
+
+		mfa = challenge("administrator", adminPassword)
+		Expect(verify(mfa.MFAToken, recoveryCodes[0]).Code).To(Equal(http.StatusUnauthorized))
+		Expect(verify(mfa.MFAToken, recoveryCodes[1]).Code).To(Equal(http.StatusOK))
+	})
+
+	It("locks the account after repeated wrong codes", func() {
+		enroll()
+		mfa := challenge("administrator", adminPassword)
+
+		for range loginMaxFailures {
+			Expect(verify(mfa.MFAToken, "000000").Code).To(Equal(http.StatusUnauthorized))
+		}
+		Expect(verify(mfa.MFAToken, "000000").Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("lets a user disable it with a valid code", func() {
+		enrollment, _ := enroll()
+
+		res := server.do(http.MethodDelete, "/api/v1/mfa/totp", map[string]any{"code": code(enrollment.Secret, 1)})
+		Expect(res.Code).To(Equal(http.StatusOK))
+
+		Expect(server.login("administrator", adminPassword).Message).To(Equal("login user success"))
+	})
+
+	It("lets an admin reset another user's enrollment", func() {
+		Expect(server.do(http.MethodPost, "/api/v1/users", map[string]any{
+			"full_name": "Clerk", "username": "clerk", "password": "Clerk-pass-7", "role": "user",
+		}).Code).To(Equal(http.StatusCreated))
+
+		Expect(server.login("clerk", "Clerk-pass-7").Code).To(Equal(http.StatusOK))
+		enroll()
+		challenge("clerk", "Clerk-pass-7")
+
+		server.loginAdmin()
+		Expect(server.do(http.MethodDelete, "/api/v1/users/clerk/mfa", nil).Code).To(Equal(http.StatusOK))
+		Expect(server.do(http.MethodDelete, "/api/v1/users/missing/mfa", nil).Code).To(Equal(http.StatusNotFound))
+
+		Expect(server.login("clerk", "Clerk-pass-7").Message).To(Equal("login user success"))
+	})
+
+	Context("when the role requires it", func() {
+		BeforeEach(func() {
+			keys, err := signing.Generate()
+			Expect(err).NotTo(HaveOccurred())
+			server = newCustomTestServer(keys, config.MFA{RequiredRoles: []string{"admin"}})
+		})
+
+		It("enrolls during login before issuing the session", func() {
+			mfa := challenge("administrator", adminPassword)
+			Expect(mfa.EnrollmentRequired).To(BeTrue())
+
+			res := verify(mfa.MFAToken, "000000")
+			Expect(res.Code).To(Equal(http.StatusBadRequest))
+			Expect(res.Message).To(Equal("two-factor enrollment is required"))
+
+			res = server.do(http.MethodPost, "/api/v1/login/mfa/enroll", map[string]any{"mfa_token": mfa.MFAToken})
+			Expect(res.Code).To(Equal(http.StatusOK))
+			enrollment := decode[domain.TOTPEnrollment](res)
+
+			res = verify(mfa.MFAToken, code(enrollment.Secret, 0))
+			Expect(res.Code).To(Equal(http.StatusOK))
+			Expect(decode[domain.TokenPair](res).RecoveryCodes).To(HaveLen(10))
+
+			res = server.do(http.MethodDelete, "/api/v1/mfa/totp", map[string]any{"code": code(enrollment.Secret, 1)})
+			Expect(res.Code).To(Equal(http.StatusBadRequest))
+			Expect(res.Message).To(Equal("two-factor authentication is required for role admin"))
+		})
+	})
+})
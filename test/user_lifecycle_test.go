@@ -0,0 +1,144 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/model/domain"
+	"net/http"
+)
+
+var _ = Describe("User lifecycle", func() {
+	var server *testServer
+
+	register := func(username string, role string) {
+		Expect(server.do(http.MethodPost, "/api/v1/users", map[string]any{
+			"full_name": username, "username": username, "password": "Sturdy-pass-1", "role": role,
+		}).Code).To(Equal(http.StatusCreated))
+	}
+
+	bearer := func(method string, path string, token string) response {
+		server.cookie = nil
+		server.csrf = nil
+		res, _ := server.doWithHeaders(method, path, nil, http.Header{"Authorization": {"Bearer " + token}})
+		return res
+	}
+
+	BeforeEach(func() {
+		server = newTestServer()
+		server.loginAdmin()
+		register("janedoe", "user")
+	})
+
+	It("deactivates a user, revokes their sessions and reactivates them", func() {
+		Expect(server.login("janedoe", "Sturdy-pass-1").Code).To(Equal(http.StatusOK))
+		tokens := server.tokens()
+
+		server.loginAdmin()
+		res := server.do(http.MethodPost, "/api/v1/users/janedoe/deactivate", nil)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(res.Message).To(Equal("deactivate user success"))
+		Expect(decode[domain.Users](server.do(http.MethodGet, "/api/v1/users/janedoe", nil)).Active).To(BeFalse())
+		Expect(server.do(http.MethodPost, "/api/v1/users/janedoe/deactivate", nil).Code).To(Equal(http.StatusConflict))
+
+		res = server.do(http.MethodPost, "/api/v1/token/refresh", map[string]any{"refresh_token": tokens.RefreshToken})
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+
+		res = bearer(http.MethodGet, "/api/v1/me", tokens.AccessToken)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("token is revoked"))
+
+		res = server.login("janedoe", "Sturdy-pass-1")
+		Expect(res.Code).To(Equal(http.StatusForbidden))
+		Expect(res.Message).To(Equal("account is deactivated"))
+		Expect(server.login("janedoe", "wrong-password").Message).To(Equal("invalid username or password"))
+
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/users/janedoe/reactivate", nil).Code).To(Equal(http.StatusOK))
+		Expect(server.do(http.MethodPost, "/api/v1/users/janedoe/reactivate", nil).Code).To(Equal(http.StatusConflict))
+		Expect(server.login("janedoe", "Sturdy-pass-1").Code).To(Equal(http.StatusOK))
+	})
+
+	It("revokes the sessions of a user whose password an admin replaces", func() {
+		update := func(newPassword string, role string) response {
+			return server.do(http.MethodPut, "/api/v1/users/janedoe", map[string]any{
+				"full_name": "janedoe", "password": newPassword, "role": role,
+			})
+		}
+
+		Expect(server.login("janedoe", "Sturdy-pass-1").Code).To(Equal(http.StatusOK))
+		tokens := server.tokens()
+		server.loginAdmin()
+		Expect(update("Sturdy-pass-1", "user").Code).To(Equal(http.StatusOK))
+		Expect(bearer(http.MethodGet, "/api/v1/me", tokens.AccessToken).Code).To(Equal(http.StatusOK))
+
+		longPassword := "A-much-longer-passphrase-than-twenty-chars-9"
+		server.loginAdmin()
+		Expect(update(longPassword, "user").Code).To(Equal(http.StatusOK))
+		Expect(server.do(http.MethodPost, "/api/v1/token/refresh", map[string]any{"refresh_token": tokens.RefreshToken}).Code).To(Equal(http.StatusUnauthorized))
+		res := bearer(http.MethodGet, "/api/v1/me", tokens.AccessToken)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("token is revoked"))
+
+		Expect(server.login("janedoe", "Sturdy-pass-1").Code).To(Equal(http.StatusBadRequest))
+		Expect(server.login("janedoe", longPassword).Code).To(Equal(http.StatusOK))
+	})
+
+	It("does not let an admin deactivate or delete themselves", func() {
+		res := server.do(http.MethodPost, "/api/v1/users/administrator/deactivate", nil)
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("cannot deactivate your own account"))
+
+		res = server.do(http.MethodDelete, "/api/v1/users/administrator", nil)
+		Expect(res.Code).To(Equal(http.StatusBadRequest))
+		Expect(res.Message).To(Equal("cannot delete your own account"))
+
+		Expect(server.do(http.MethodPost, "/api/v1/users/missing/deactivate", nil).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("keeps at least one active admin", func() {
+		demote := map[string]any{"full_name": "Administrator", "username": "administrator", "password": "Sturdy-pass-1", "role": "user"}
+		res := server.do(http.MethodPut, "/api/v1/users/administrator", demote)
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Message).To(Equal("cannot demote the last active admin"))
+
+		register("backupadmin", "admin")
+		Expect(server.login("backupadmin", "Sturdy-pass-1").Code).To(Equal(http.StatusOK))
+		backup := server.tokens()
+
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/users/backupadmin/deactivate", nil).Code).To(Equal(http.StatusOK))
+
+		res = server.do(http.MethodPut, "/api/v1/users/administrator", demote)
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Message).To(Equal("cannot demote the last active admin"))
+
+		res = bearer(http.MethodDelete, "/api/v1/users/administrator", backup.AccessToken)
+		Expect(res.Code).To(Equal(http.StatusUnauthorized))
+		Expect(res.Message).To(Equal("token is revoked"))
+
+		server.loginAdmin()
+		Expect(server.do(http.MethodPost, "/api/v1/users/backupadmin/reactivate", nil).Code).To(Equal(http.StatusOK))
+		Expect(server.do(http.MethodPut, "/api/v1/users/administrator", demote).Code).To(Equal(http.StatusOK))
+	})
+
+	It("keeps users with recorded activity and their usernames", func() {
+		Expect(server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "ram"}).Code).To(Equal(http.StatusCreated))
+		Expect(server.login("janedoe", "Sturdy-pass-1").Code).To(Equal(http.StatusOK))
+		Expect(server.do(http.MethodPost, "/api/v1/items", map[string]any{
+			"name": "DDR4 8GB", "category_id": 1, "quantity": 10, "price": 25, "specification": "DDR4",
+		}).Code).To(Equal(http.StatusCreated))
+
+		server.loginAdmin()
+		res := server.do(http.MethodDelete, "/api/v1/users/janedoe", nil)
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Message).To(Equal("user has recorded activity, deactivate it instead"))
+
+		register("tempuser", "user")
+		Expect(server.do(http.MethodDelete, "/api/v1/users/tempuser", nil).Code).To(Equal(http.StatusOK))
+		res = server.do(http.MethodPost, "/api/v1/users", map[string]any{
+			"full_name": "Someone Else", "username": "tempuser", "password": "Sturdy-pass-1", "role": "user",
+		})
+		Expect(res.Code).To(Equal(http.StatusConflict))
+		Expect(res.Message).To(Equal("username is already taken"))
+	})
+})
@@ -0,0 +1,142 @@
+package test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"inventory-management-system/model/domain"
+	"net/http"
+)
+
+var _ = Describe("Users", func() {
+	var server *testServer
+
+	BeforeEach(func() {
+		server = newTestServer()
+	})
+
+	Describe("login", func() {
+		It("issues a session cookie for valid credentials", func() {
+			res := server.login("administrator", adminPassword)
+
+			Expect(res.Code).To(Equal(http.StatusOK))
+			Expect(server.cookie).NotTo(BeNil())
+			Expect(server.cookie.Value).NotTo(BeEmpty())
+		})
+
+		It("rejects a wrong password", func() {
+			res := server.login("administrator", "wrong-password")
+
+			Expect(res.Code).To(Equal(http.StatusBadRequest))
+			Expect(res.Message).To(Equal("invalid username or password"))
+			Expect(server.cookie).To(BeNil())
+		})
+
+		It("does not reveal whether a user exists", func() {
+			res := server.login("nobody", adminPassword)
+
+			Expect(res.Code).To(Equal(http.StatusBadRequest))
+			Expect(res.Message).To(Equal("invalid username or password"))
+		})
+
+		It("rejects a request without credentials", func() {
+			res := server.do(http.MethodPost, "/api/v1/login", map[string]any{})
+
+			Expect(res.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("role based access", func() {
+		It("requires a session for protected routes", func() {
+			res := server.do(http.MethodGet, "/api/v1/items", nil)
+
+			Expect(res.Code).To(Equal(http.StatusUnauthorized))
+			Expect(res.Message).To(Equal("session token is empty"))
+		})
+
+		It("lets an admin manage users", func() {
+			server.loginAdmin()
+
+			res := server.do(http.MethodPost, "/api/v1/users", map[string]any{
+				"full_name": "Jane Doe",
+				"username":  "janedoe",
+				"password":  "Jane-pass-7",
+				"role":      "user",
+			})
+			Expect(res.Code).To(Equal(http.StatusCreated))
+
+			res = server.do(http.MethodGet, "/api/v1/users/janedoe", nil)
+			Expect(res.Code).To(Equal(http.StatusOK))
+			user := decode[domain.Users](res)
+			Expect(user.FullName).To(Equal("Jane Doe"))
+			Expect(user.Role).To(Equal("user"))
+			Expect(user.Password).To(Equal("-"))
+		})
+
+		It("rejects a duplicate username", func() {
+			server.loginAdmin()
+
+			res := server.do(http.MethodPost, "/api/v1/users", map[string]any{
+				"full_name": "Another Admin",
+				"username":  "administrator",
+				"password":  "Jane-pass-7",
+				"role":      "admin",
+			})
+
+			Expect(res.Code).To(Equal(http.StatusConflict))
+			Expect(res.Problem.Code).To(Equal("USERNAME_TAKEN"))
+			Expect(res.Message).To(Equal("username is already taken"))
+		})
+
+		It("rejects an email that is already in use", func() {
+			server.loginAdmin()
+
+			register := func(username string, email string) response {
+				return server.do(http.MethodPost, "/api/v1/users", map[string]any{
+					"full_name": username, "username": username, "password": "Jane-pass-7", "role": "user", "email": email,
+				})
+			}
+			Expect(register("janedoe", "jane@example.com").Code).To(Equal(http.StatusCreated))
+			Expect(register("bobby01", "").Code).To(Equal(http.StatusCreated))
+			Expect(register("carol01", "").Code).To(Equal(http.StatusCreated))
+
+			res := register("jane002", "Jane@Example.com")
+			Expect(res.Code).To(Equal(http.StatusConflict))
+			Expect(res.Problem.Code).To(Equal("EMAIL_TAKEN"))
+			Expect(res.Message).To(Equal("email is already in use"))
+
+			update := func(username string, email string) response {
+				return server.do(http.MethodPut, "/api/v1/users/"+username, map[string]any{
+					"full_name": username, "password": "Jane-pass-7", "role": "user", "email": email,
+				})
+			}
+			res = update("bobby01", "JANE@example.com")
+			Expect(res.Code).To(Equal(http.StatusConflict))
+			Expect(res.Problem.Code).To(Equal("EMAIL_TAKEN"))
+			Expect(update("janedoe", "jane@example.com").Code).To(Equal(http.StatusOK))
+			Expect(update("bobby01", "bob@example.com").Code).To(Equal(http.StatusOK))
+
+			user := decode[domain.Users](server.do(http.MethodGet, "/api/v1/users/carol01", nil))
+			Expect(user.Email).To(BeNil())
+		})
+
+		It("keeps regular users out of user management", func() {
+			server.loginAdmin()
+			res := server.do(http.MethodPost, "/api/v1/users", map[string]any{
+				"full_name": "Jane Doe",
+				"username":  "janedoe",
+				"password":  "Jane-pass-7",
+				"role":      "user",
+			})
+			Expect(res.Code).To(Equal(http.StatusCreated))
+
+			Expect(server.login("janedoe", "Jane-pass-7").Code).To(Equal(http.StatusOK))
+
+			res = server.do(http.MethodGet, "/api/v1/users", nil)
+			Expect(res.Code).To(Equal(http.StatusUnauthorized))
+			Expect(res.Message).To(Equal("user is not admin"))
+
+			res = server.do(http.MethodPost, "/api/v1/category", map[string]any{"name": "ram"})
+			Expect(res.Code).To(Equal(http.StatusCreated))
+		})
+	})
+})
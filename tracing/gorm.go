@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"errors"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+type gormPlugin struct{}
+
+func NewGormPlugin() gorm.Plugin {
+	return &gormPlugin{}
+}
+
+func (g *gormPlugin) Name() string {
+	return "tracing"
+}
+
+func (g *gormPlugin) Initialize(db *gorm.DB) error {
+	register := []error{
+		db.Callback().Create().Before("gorm:create").Register("tracing:before_create", before("gorm.Create")),
+		db.Callback().Create().After("gorm:create").Register("tracing:after_create", after),
+		db.Callback().Query().Before("gorm:query").Register("tracing:before_query", before("gorm.Query")),
+		db.Callback().Query().After("gorm:query").Register("tracing:after_query", after),
+		db.Callback().Update().Before("gorm:update").Register("tracing:before_update", before("gorm.Update")),
+		db.Callback().Update().After("gorm:update").Register("tracing:after_update", after),
+		db.Callback().Delete().Before("gorm:delete").Register("tracing:before_delete", before("gorm.Delete")),
+		db.Callback().Delete().After("gorm:delete").Register("tracing:after_delete", after),
+		db.Callback().Row().Before("gorm:row").Register("tracing:before_row", before("gorm.Row")),
+		db.Callback().Row().After("gorm:row").Register("tracing:after_row", after),
+		db.Callback().Raw().Before("gorm:raw").Register("tracing:before_raw", before("gorm.Raw")),
+		db.Callback().Raw().After("gorm:raw").Register("tracing:after_raw", after),
+	}
+
+	return errors.Join(register...)
+}
+
+func before(name string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			return
+		}
+
+		ctx, span := Tracer().Start(db.Statement.Context, name, trace.WithSpanKind(trace.SpanKindClient))
+		db.Statement.Context = ctx
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func after(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBSystemKey.String(db.Dialector.Name()),
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.String("db.table", db.Statement.Table),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+
+	if !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		RecordError(span, db.Error)
+	}
+}